@@ -0,0 +1,291 @@
+package beaconclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/bsn-eng/pon-golang-types/common"
+)
+
+const (
+	MediaTypeJSON = "application/json"
+	MediaTypeSSZ  = "application/octet-stream"
+
+	ConsensusVersionHeader = "Eth-Consensus-Version"
+
+	defaultClientTimeout = 10 * time.Second
+)
+
+var ErrNoEndpoints = errors.New("no beacon node endpoints set")
+
+// APIError is an error response returned by a beacon node
+type APIError struct {
+	Endpoint   string
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("beacon node %s returned status %d: %s", e.Endpoint, e.StatusCode, e.Message)
+}
+
+// Beacon Node Client Parameters
+type ClientOpts struct {
+	// Endpoints are tried in order, falling back to the next one on failure
+	Endpoints []string
+	Timeout   time.Duration
+	// PreferSSZ requests SSZ encoded responses from endpoints that support it
+	PreferSSZ bool
+}
+
+// Client is a beacon node API client with fallback beacon nodes
+type Client struct {
+	endpoints  []string
+	httpClient *http.Client
+	preferSSZ  bool
+}
+
+type apiResponse struct {
+	contentType      string
+	consensusVersion string
+	body             []byte
+}
+
+func NewClient(opts ClientOpts) (*Client, error) {
+	if len(opts.Endpoints) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	endpoints := make([]string, len(opts.Endpoints))
+	for i, endpoint := range opts.Endpoints {
+		endpoints[i] = strings.TrimSuffix(endpoint, "/")
+	}
+
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = defaultClientTimeout
+	}
+
+	return &Client{
+		endpoints:  endpoints,
+		httpClient: &http.Client{Timeout: timeout},
+		preferSSZ:  opts.PreferSSZ,
+	}, nil
+}
+
+// Genesis returns the genesis of the chain
+func (c *Client) Genesis(ctx context.Context) (*GenesisData, error) {
+	res := new(GetGenesisResponse)
+	if err := c.getJSON(ctx, "/eth/v1/beacon/genesis", nil, res); err != nil {
+		return nil, err
+	}
+	if res.Data == nil {
+		return nil, errors.New("genesis data missing")
+	}
+	return res.Data, nil
+}
+
+// SyncStatus returns the sync status of the beacon node
+func (c *Client) SyncStatus(ctx context.Context) (*SyncStatusData, error) {
+	res := new(GetSyncStatusResponse)
+	if err := c.getJSON(ctx, "/eth/v1/node/syncing", nil, res); err != nil {
+		return nil, err
+	}
+	if res.Data == nil {
+		return nil, errors.New("sync status data missing")
+	}
+	return res.Data, nil
+}
+
+// Validators returns the validators in the state, filtered by validator index or public key if ids are given
+func (c *Client) Validators(ctx context.Context, stateID string, ids []string) ([]*ValidatorData, error) {
+	query := url.Values{}
+	if len(ids) > 0 {
+		query.Set("id", strings.Join(ids, ","))
+	}
+
+	res := new(GetValidatorsResponse)
+	if err := c.getJSON(ctx, fmt.Sprintf("/eth/v1/beacon/states/%s/validators", stateID), query, res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// ProposerDuties returns the block proposers of every slot in the epoch
+func (c *Client) ProposerDuties(ctx context.Context, epoch uint64) ([]*ProposerDutyData, error) {
+	duties, _, err := c.ProposerDutiesWithDependentRoot(ctx, epoch)
+	return duties, err
+}
+
+// ProposerDutiesWithDependentRoot returns the block proposers of every slot in the epoch along with
+// the block root the duties were computed from, which changes if the duties are reorged
+func (c *Client) ProposerDutiesWithDependentRoot(ctx context.Context, epoch uint64) ([]*ProposerDutyData, string, error) {
+	res := new(GetProposerDutiesResponse)
+	if err := c.getJSON(ctx, fmt.Sprintf("/eth/v1/validator/duties/proposer/%d", epoch), nil, res); err != nil {
+		return nil, "", err
+	}
+	return res.Data, res.DependentRoot, nil
+}
+
+// Randao returns the randao mix of the state
+func (c *Client) Randao(ctx context.Context, stateID string) (*RandaoData, error) {
+	res := new(GetRandaoResponse)
+	if err := c.getJSON(ctx, fmt.Sprintf("/eth/v1/beacon/states/%s/randao", stateID), nil, res); err != nil {
+		return nil, err
+	}
+	if res.Data == nil {
+		return nil, errors.New("randao data missing")
+	}
+	return res.Data, nil
+}
+
+// Block returns the signed beacon block, requested as SSZ if the client prefers SSZ
+func (c *Client) Block(ctx context.Context, blockID string) (*GetBlockResponse, error) {
+	accept := MediaTypeJSON
+	if c.preferSSZ {
+		accept = fmt.Sprintf("%s;q=1.0,%s;q=0.9", MediaTypeSSZ, MediaTypeJSON)
+	}
+
+	raw, err := c.get(ctx, fmt.Sprintf("/eth/v2/beacon/blocks/%s", blockID), nil, accept)
+	if err != nil {
+		return nil, err
+	}
+
+	if raw.contentType != MediaTypeSSZ {
+		res := new(GetBlockResponse)
+		if err := json.Unmarshal(raw.body, res); err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+
+	version, err := common.DataVersionFromName(raw.consensusVersion)
+	if err != nil {
+		return nil, err
+	}
+	block := new(common.VersionedSignedBeaconBlock)
+	if err := block.UnmarshalSSZWithVersion(version, raw.body); err != nil {
+		return nil, err
+	}
+	return &GetBlockResponse{
+		Version: raw.consensusVersion,
+		Data:    block,
+	}, nil
+}
+
+// BlockHeader returns the signed header of the beacon block
+func (c *Client) BlockHeader(ctx context.Context, blockID string) (*BlockHeaderData, error) {
+	res := new(GetBlockHeaderResponse)
+	if err := c.getJSON(ctx, fmt.Sprintf("/eth/v1/beacon/headers/%s", blockID), nil, res); err != nil {
+		return nil, err
+	}
+	if res.Data == nil {
+		return nil, errors.New("block header data missing")
+	}
+	return res.Data, nil
+}
+
+// ExpectedWithdrawals returns the withdrawals expected in the block built on top of the state
+func (c *Client) ExpectedWithdrawals(ctx context.Context, stateID string) (Withdrawals, error) {
+	res := new(GetWithdrawalsResponse)
+	if err := c.getJSON(ctx, fmt.Sprintf("/eth/v1/builder/states/%s/expected_withdrawals", stateID), nil, res); err != nil {
+		return nil, err
+	}
+	if res.Data == nil {
+		return Withdrawals{}, nil
+	}
+	return *res.Data, nil
+}
+
+func (c *Client) getJSON(ctx context.Context, path string, query url.Values, dst interface{}) error {
+	raw, err := c.get(ctx, path, query, MediaTypeJSON)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw.body, dst)
+}
+
+// get requests the path from each beacon node in turn until one responds successfully. The next beacon node
+// is only tried on connection errors and server errors, other error responses are returned directly
+func (c *Client) get(ctx context.Context, path string, query url.Values, accept string) (*apiResponse, error) {
+	var errs []error
+	for _, endpoint := range c.endpoints {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		res, err := c.getFrom(ctx, endpoint, path, query, accept)
+		if err == nil {
+			return res, nil
+		}
+
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode < http.StatusInternalServerError {
+			return nil, err
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		errs = append(errs, err)
+	}
+	return nil, errors.Join(errs...)
+}
+
+func (c *Client) getFrom(ctx context.Context, endpoint, path string, query url.Values, accept string) (*apiResponse, error) {
+	target := endpoint + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &APIError{
+			Endpoint:   endpoint,
+			StatusCode: resp.StatusCode,
+		}
+		var errResponse struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &errResponse) == nil && errResponse.Message != "" {
+			apiErr.Message = errResponse.Message
+		} else {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+		return nil, apiErr
+	}
+
+	contentType := MediaTypeJSON
+	if mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err == nil {
+		contentType = mediaType
+	}
+
+	return &apiResponse{
+		contentType:      contentType,
+		consensusVersion: resp.Header.Get(ConsensusVersionHeader),
+		body:             body,
+	}, nil
+}
@@ -1,26 +1,96 @@
 package beaconclient
 
 import (
+	"errors"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	commonTypes "github.com/bsn-eng/pon-golang-types/common"
 )
 
 type PubkeyHex string
 
 type ValidatorData struct {
 	Index     uint64           `json:"index,string"`
-	Balance   string           `json:"balance"` // In gwei.
-	Status    string           `json:"status"`
+	Balance   phase0.Gwei      `json:"balance,string"`
+	Status    ValidatorStatus  `json:"status"`
 	Validator ValidatorDetails `json:"validator"`
 }
 
 type ValidatorDetails struct {
-	Pubkey string `json:"pubkey"`
+	Pubkey                     string       `json:"pubkey"`
+	WithdrawalCredentials      string       `json:"withdrawal_credentials"`
+	EffectiveBalance           phase0.Gwei  `json:"effective_balance,string"`
+	Slashed                    bool         `json:"slashed"`
+	ActivationEligibilityEpoch phase0.Epoch `json:"activation_eligibility_epoch,string"`
+	ActivationEpoch            phase0.Epoch `json:"activation_epoch,string"`
+	ExitEpoch                  phase0.Epoch `json:"exit_epoch,string"`
+	WithdrawableEpoch          phase0.Epoch `json:"withdrawable_epoch,string"`
 }
 
 type HeadEventData struct {
-	Slot  uint64 `json:"slot,string"`
-	Block string `json:"block"`
-	State string `json:"state"`
+	Slot                      uint64 `json:"slot,string"`
+	Block                     string `json:"block"`
+	State                     string `json:"state"`
+	EpochTransition           bool   `json:"epoch_transition"`
+	PreviousDutyDependentRoot string `json:"previous_duty_dependent_root"`
+	CurrentDutyDependentRoot  string `json:"current_duty_dependent_root"`
+	ExecutionOptimistic       bool   `json:"execution_optimistic"`
+}
+
+type BlockEventData struct {
+	Slot                uint64 `json:"slot,string"`
+	Block               string `json:"block"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+}
+
+type FinalizedCheckpointEventData struct {
+	Block               string `json:"block"`
+	State               string `json:"state"`
+	Epoch               uint64 `json:"epoch,string"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+}
+
+type ChainReorgEventData struct {
+	Slot                uint64 `json:"slot,string"`
+	Depth               uint64 `json:"depth,string"`
+	OldHeadBlock        string `json:"old_head_block"`
+	NewHeadBlock        string `json:"new_head_block"`
+	OldHeadState        string `json:"old_head_state"`
+	NewHeadState        string `json:"new_head_state"`
+	Epoch               uint64 `json:"epoch,string"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+}
+
+type BlobSidecarEventData struct {
+	BlockRoot     string `json:"block_root"`
+	Index         uint64 `json:"index,string"`
+	Slot          uint64 `json:"slot,string"`
+	KZGCommitment string `json:"kzg_commitment"`
+	VersionedHash string `json:"versioned_hash"`
+}
+
+type SignedVoluntaryExitData struct {
+	Message   *VoluntaryExitData `json:"message"`
+	Signature string             `json:"signature"`
+}
+
+type VoluntaryExitData struct {
+	Epoch          uint64 `json:"epoch,string"`
+	ValidatorIndex uint64 `json:"validator_index,string"`
+}
+
+type SignedBLSToExecutionChangeData struct {
+	Message   *BLSToExecutionChangeData `json:"message"`
+	Signature string                    `json:"signature"`
+}
+
+type BLSToExecutionChangeData struct {
+	ValidatorIndex     uint64 `json:"validator_index,string"`
+	FromBLSPubkey      string `json:"from_bls_pubkey"`
+	ToExecutionAddress string `json:"to_execution_address"`
 }
 
 type PayloadAttributesEventData struct {
@@ -45,6 +115,38 @@ type GenesisData struct {
 	GenesisForkVersion    string `json:"genesis_fork_version"`
 }
 
+// BuilderDomain computes the DOMAIN_APPLICATION_BUILDER domain from the genesis fork version
+func (g *GenesisData) BuilderDomain() (phase0.Domain, error) {
+	genesisForkVersion, err := g.genesisForkVersion()
+	if err != nil {
+		return phase0.Domain{}, err
+	}
+	return commonTypes.ComputeBuilderDomain(genesisForkVersion)
+}
+
+// BeaconProposerDomain computes the DOMAIN_BEACON_PROPOSER domain for the fork version from the genesis validators root
+func (g *GenesisData) BeaconProposerDomain(forkVersion phase0.Version) (phase0.Domain, error) {
+	genesisValidatorsRoot, err := hexutil.Decode(g.GenesisValidatorsRoot)
+	if err != nil {
+		return phase0.Domain{}, err
+	}
+	if len(genesisValidatorsRoot) != 32 {
+		return phase0.Domain{}, errors.New("invalid genesis validators root")
+	}
+	return commonTypes.ComputeBeaconProposerDomain(forkVersion, phase0.Root(genesisValidatorsRoot))
+}
+
+func (g *GenesisData) genesisForkVersion() (phase0.Version, error) {
+	genesisForkVersion, err := hexutil.Decode(g.GenesisForkVersion)
+	if err != nil {
+		return phase0.Version{}, err
+	}
+	if len(genesisForkVersion) != 4 {
+		return phase0.Version{}, errors.New("invalid genesis fork version")
+	}
+	return phase0.Version(genesisForkVersion), nil
+}
+
 type SyncStatusData struct {
 	HeadSlot  uint64 `json:"head_slot,string"`
 	IsSyncing bool   `json:"is_syncing"`
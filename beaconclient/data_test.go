@@ -0,0 +1,39 @@
+package beaconclient
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+func TestGenesisDataDomains(t *testing.T) {
+	genesis := &GenesisData{
+		GenesisValidatorsRoot: "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95",
+		GenesisForkVersion:    "0x00000000",
+	}
+	domain, err := genesis.BuilderDomain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "0x00000001f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a9"; phase0.Root(domain).String() != expected {
+		t.Fatalf("expected builder domain %s, got %#x", expected, domain)
+	}
+
+	// The beacon proposer domain of mainnet capella, whose fork data root starts with the capella fork digest 0xbba4da96
+	domain, err = genesis.BeaconProposerDomain(phase0.Version{0x03})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "0x00000000bba4da96354c9f25476cf1bc69bf583a7f9e0af049305b62de676640"; phase0.Root(domain).String() != expected {
+		t.Fatalf("expected beacon proposer domain %s, got %#x", expected, domain)
+	}
+
+	genesis.GenesisForkVersion = "0x00"
+	if _, err := genesis.BuilderDomain(); err == nil {
+		t.Fatal("expected an error for a short fork version")
+	}
+	genesis.GenesisValidatorsRoot = "0x01"
+	if _, err := genesis.BeaconProposerDomain(phase0.Version{}); err == nil {
+		t.Fatal("expected an error for a short genesis validators root")
+	}
+}
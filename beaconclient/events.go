@@ -1,10 +1,172 @@
 package beaconclient
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	TopicHead                 = "head"
+	TopicPayloadAttributes    = "payload_attributes"
+	TopicBlock                = "block"
+	TopicFinalizedCheckpoint  = "finalized_checkpoint"
+	TopicChainReorg           = "chain_reorg"
+	TopicBlobSidecar          = "blob_sidecar"
+	TopicVoluntaryExit        = "voluntary_exit"
+	TopicBLSToExecutionChange = "bls_to_execution_change"
+)
+
 type PayloadAttributesEvent struct {
-	Version string                     `json:"version"`
-	Data *PayloadAttributesEventData `json:"data"`
+	Version string                      `json:"version"`
+	Data    *PayloadAttributesEventData `json:"data"`
 }
 
 type HeadEvent struct {
 	Data *HeadEventData `json:"data"`
-}
\ No newline at end of file
+}
+
+type BlockEvent struct {
+	Data *BlockEventData `json:"data"`
+}
+
+type FinalizedCheckpointEvent struct {
+	Data *FinalizedCheckpointEventData `json:"data"`
+}
+
+type ChainReorgEvent struct {
+	Data *ChainReorgEventData `json:"data"`
+}
+
+type BlobSidecarEvent struct {
+	Data *BlobSidecarEventData `json:"data"`
+}
+
+type VoluntaryExitEvent struct {
+	Data *SignedVoluntaryExitData `json:"data"`
+}
+
+type BLSToExecutionChangeEvent struct {
+	Data *SignedBLSToExecutionChangeData `json:"data"`
+}
+
+// EventDispatcher routes raw event stream payloads to the handler of their event name.
+// Events without a handler are ignored
+type EventDispatcher struct {
+	OnHead                 func(*HeadEvent)
+	OnPayloadAttributes    func(*PayloadAttributesEvent)
+	OnBlock                func(*BlockEvent)
+	OnFinalizedCheckpoint  func(*FinalizedCheckpointEvent)
+	OnChainReorg           func(*ChainReorgEvent)
+	OnBlobSidecar          func(*BlobSidecarEvent)
+	OnVoluntaryExit        func(*VoluntaryExitEvent)
+	OnBLSToExecutionChange func(*BLSToExecutionChangeEvent)
+}
+
+// Dispatch decodes the data of the event and calls the handler of the event name
+func (d *EventDispatcher) Dispatch(event string, data []byte) error {
+	if !IsEventTopic(event) {
+		return fmt.Errorf("unsupported event %s", event)
+	}
+	if !d.handles(event) {
+		return nil
+	}
+
+	decoded, err := DecodeEvent(event, data)
+	if err != nil {
+		return err
+	}
+
+	switch e := decoded.(type) {
+	case *HeadEvent:
+		d.OnHead(e)
+	case *PayloadAttributesEvent:
+		d.OnPayloadAttributes(e)
+	case *BlockEvent:
+		d.OnBlock(e)
+	case *FinalizedCheckpointEvent:
+		d.OnFinalizedCheckpoint(e)
+	case *ChainReorgEvent:
+		d.OnChainReorg(e)
+	case *BlobSidecarEvent:
+		d.OnBlobSidecar(e)
+	case *VoluntaryExitEvent:
+		d.OnVoluntaryExit(e)
+	case *BLSToExecutionChangeEvent:
+		d.OnBLSToExecutionChange(e)
+	}
+	return nil
+}
+
+func (d *EventDispatcher) handles(event string) bool {
+	switch event {
+	case TopicHead:
+		return d.OnHead != nil
+	case TopicPayloadAttributes:
+		return d.OnPayloadAttributes != nil
+	case TopicBlock:
+		return d.OnBlock != nil
+	case TopicFinalizedCheckpoint:
+		return d.OnFinalizedCheckpoint != nil
+	case TopicChainReorg:
+		return d.OnChainReorg != nil
+	case TopicBlobSidecar:
+		return d.OnBlobSidecar != nil
+	case TopicVoluntaryExit:
+		return d.OnVoluntaryExit != nil
+	case TopicBLSToExecutionChange:
+		return d.OnBLSToExecutionChange != nil
+	default:
+		return false
+	}
+}
+
+// IsEventTopic reports whether the event topic is one of the supported beacon node event topics
+func IsEventTopic(topic string) bool {
+	switch topic {
+	case TopicHead, TopicPayloadAttributes, TopicBlock, TopicFinalizedCheckpoint,
+		TopicChainReorg, TopicBlobSidecar, TopicVoluntaryExit, TopicBLSToExecutionChange:
+		return true
+	default:
+		return false
+	}
+}
+
+// DecodeEvent decodes the data of an event stream frame into the event type of the event name.
+// The payload_attributes data carries its version alongside the data, other events only carry the data
+func DecodeEvent(event string, data []byte) (interface{}, error) {
+	var decoded, target interface{}
+
+	switch event {
+	case TopicHead:
+		e := &HeadEvent{Data: new(HeadEventData)}
+		decoded, target = e, e.Data
+	case TopicPayloadAttributes:
+		e := new(PayloadAttributesEvent)
+		decoded, target = e, e
+	case TopicBlock:
+		e := &BlockEvent{Data: new(BlockEventData)}
+		decoded, target = e, e.Data
+	case TopicFinalizedCheckpoint:
+		e := &FinalizedCheckpointEvent{Data: new(FinalizedCheckpointEventData)}
+		decoded, target = e, e.Data
+	case TopicChainReorg:
+		e := &ChainReorgEvent{Data: new(ChainReorgEventData)}
+		decoded, target = e, e.Data
+	case TopicBlobSidecar:
+		e := &BlobSidecarEvent{Data: new(BlobSidecarEventData)}
+		decoded, target = e, e.Data
+	case TopicVoluntaryExit:
+		e := &VoluntaryExitEvent{Data: new(SignedVoluntaryExitData)}
+		decoded, target = e, e.Data
+	case TopicBLSToExecutionChange:
+		e := &BLSToExecutionChangeEvent{Data: new(SignedBLSToExecutionChangeData)}
+		decoded, target = e, e.Data
+	default:
+		return nil, fmt.Errorf("unsupported event %s", event)
+	}
+
+	if err := json.Unmarshal(data, target); err != nil {
+		return nil, fmt.Errorf("invalid %s event: %w", event, err)
+	}
+	return decoded, nil
+}
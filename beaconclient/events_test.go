@@ -0,0 +1,125 @@
+package beaconclient
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDecodeEvent(t *testing.T) {
+	tests := []struct {
+		event string
+		data  string
+		check func(decoded interface{}) bool
+	}{
+		{TopicHead, `{"slot":"10","block":"0x01","epoch_transition":true}`, func(decoded interface{}) bool {
+			e, ok := decoded.(*HeadEvent)
+			return ok && e.Data.Slot == 10 && e.Data.Block == "0x01" && e.Data.EpochTransition
+		}},
+		{TopicPayloadAttributes, `{"version":"electra","data":{"proposal_slot":"11"}}`, func(decoded interface{}) bool {
+			e, ok := decoded.(*PayloadAttributesEvent)
+			return ok && e.Version == "electra" && e.Data.ProposalSlot == 11
+		}},
+		{TopicBlock, `{"slot":"12","block":"0x02"}`, func(decoded interface{}) bool {
+			e, ok := decoded.(*BlockEvent)
+			return ok && e.Data.Slot == 12
+		}},
+		{TopicFinalizedCheckpoint, `{"block":"0x03","epoch":"2"}`, func(decoded interface{}) bool {
+			e, ok := decoded.(*FinalizedCheckpointEvent)
+			return ok && e.Data.Epoch == 2
+		}},
+		{TopicChainReorg, `{"slot":"13","depth":"2","new_head_block":"0x04"}`, func(decoded interface{}) bool {
+			e, ok := decoded.(*ChainReorgEvent)
+			return ok && e.Data.Depth == 2 && e.Data.NewHeadBlock == "0x04"
+		}},
+		{TopicBlobSidecar, `{"block_root":"0x05","index":"1","slot":"14"}`, func(decoded interface{}) bool {
+			e, ok := decoded.(*BlobSidecarEvent)
+			return ok && e.Data.Index == 1 && e.Data.Slot == 14
+		}},
+		{TopicVoluntaryExit, `{"message":{"epoch":"3","validator_index":"7"},"signature":"0x06"}`, func(decoded interface{}) bool {
+			e, ok := decoded.(*VoluntaryExitEvent)
+			return ok && e.Data.Message.ValidatorIndex == 7
+		}},
+		{TopicBLSToExecutionChange, `{"message":{"validator_index":"8","to_execution_address":"0x07"}}`, func(decoded interface{}) bool {
+			e, ok := decoded.(*BLSToExecutionChangeEvent)
+			return ok && e.Data.Message.ValidatorIndex == 8
+		}},
+	}
+	for _, test := range tests {
+		decoded, err := DecodeEvent(test.event, []byte(test.data))
+		if err != nil {
+			t.Fatalf("%s: %v", test.event, err)
+		}
+		if !test.check(decoded) {
+			t.Fatalf("%s: unexpected event %+v", test.event, decoded)
+		}
+	}
+
+	if _, err := DecodeEvent("unknown", []byte(`{}`)); err == nil {
+		t.Fatal("expected an error for an unknown event")
+	}
+	if _, err := DecodeEvent(TopicHead, []byte(`{"slot":10}`)); err == nil {
+		t.Fatal("expected an error for malformed data")
+	}
+}
+
+func TestEventDispatcher(t *testing.T) {
+	var reorg *ChainReorgEvent
+	var finalized *FinalizedCheckpointEvent
+	dispatcher := &EventDispatcher{
+		OnChainReorg:          func(e *ChainReorgEvent) { reorg = e },
+		OnFinalizedCheckpoint: func(e *FinalizedCheckpointEvent) { finalized = e },
+	}
+
+	if err := dispatcher.Dispatch(TopicChainReorg, []byte(`{"slot":"5","depth":"1"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if reorg == nil || reorg.Data.Slot != 5 {
+		t.Fatal("reorg handler not called")
+	}
+	if finalized != nil {
+		t.Fatal("finalized checkpoint handler called for a reorg")
+	}
+
+	// Events without a handler are ignored, even when malformed
+	if err := dispatcher.Dispatch(TopicBlock, []byte(`not json`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := dispatcher.Dispatch(TopicFinalizedCheckpoint, []byte(`not json`)); err == nil {
+		t.Fatal("expected an error for malformed data")
+	}
+	if err := dispatcher.Dispatch("unknown", []byte(`{}`)); err == nil {
+		t.Fatal("expected an error for an unknown event")
+	}
+}
+
+func TestEventSubscriberDispatchesOtherTopics(t *testing.T) {
+	node := NewFakeBeaconNode()
+	defer node.Close()
+
+	blocks := make(chan *BlockEvent, 1)
+	subscriber, err := NewEventSubscriber(EventSubscriberOpts{
+		Endpoints:  []string{node.URL()},
+		Topics:     []string{TopicHead, TopicBlock},
+		Dispatcher: &EventDispatcher{OnBlock: func(e *BlockEvent) { blocks <- e }},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go subscriber.Run(ctx)
+
+	testWaitForEventStreams(t, node, 1)
+	if err := node.PublishEvent(TopicBlock, &BlockEventData{Slot: 9, Block: "0x01"}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case event := <-blocks:
+		if event.Data.Slot != 9 {
+			t.Fatalf("expected slot 9, got %d", event.Data.Slot)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no block event dispatched")
+	}
+}
@@ -0,0 +1,126 @@
+package beaconclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// fakeEventStream is an event stream connection to the FakeBeaconNode
+type fakeEventStream struct {
+	topics     map[string]bool
+	frames     chan []byte
+	disconnect chan struct{}
+	done       chan struct{}
+}
+
+// PublishEvent sends the event data to every event stream subscribed to the topic
+func (n *FakeBeaconNode) PublishEvent(topic string, data interface{}) error {
+	return n.publish(topic, data)
+}
+
+// PublishHeadEvent sends a head event to every event stream subscribed to the head topic
+func (n *FakeBeaconNode) PublishHeadEvent(data *HeadEventData) error {
+	return n.publish(TopicHead, data)
+}
+
+// PublishPayloadAttributesEvent sends a payload attributes event to every event stream subscribed to the payload_attributes topic
+func (n *FakeBeaconNode) PublishPayloadAttributesEvent(event *PayloadAttributesEvent) error {
+	return n.publish(TopicPayloadAttributes, event)
+}
+
+// EventStreams returns the number of connected event streams
+func (n *FakeBeaconNode) EventStreams() int {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return len(n.eventStreams)
+}
+
+// DisconnectEventStreams ends every connected event stream, as a beacon node restart would
+func (n *FakeBeaconNode) DisconnectEventStreams() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for stream := range n.eventStreams {
+		close(stream.disconnect)
+		delete(n.eventStreams, stream)
+	}
+}
+
+func (n *FakeBeaconNode) publish(topic string, data interface{}) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	frame := []byte(fmt.Sprintf("event: %s\ndata: %s\n\n", topic, encoded))
+
+	n.mu.RLock()
+	var streams []*fakeEventStream
+	for stream := range n.eventStreams {
+		if stream.topics[topic] {
+			streams = append(streams, stream)
+		}
+	}
+	n.mu.RUnlock()
+
+	for _, stream := range streams {
+		select {
+		case stream.frames <- frame:
+		case <-stream.done:
+		}
+	}
+	return nil
+}
+
+func (n *FakeBeaconNode) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming unsupported")
+		return
+	}
+
+	stream := &fakeEventStream{
+		topics:     make(map[string]bool),
+		frames:     make(chan []byte, 64),
+		disconnect: make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	for _, topic := range strings.Split(r.URL.Query().Get("topics"), ",") {
+		if !IsEventTopic(topic) {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid topic %s", topic))
+			return
+		}
+		stream.topics[topic] = true
+	}
+
+	n.mu.Lock()
+	n.eventStreams[stream] = true
+	n.mu.Unlock()
+	defer func() {
+		n.mu.Lock()
+		delete(n.eventStreams, stream)
+		n.mu.Unlock()
+		close(stream.done)
+	}()
+
+	w.Header().Set("Content-Type", MediaTypeEventStream)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case frame := <-stream.frames:
+			if _, err := w.Write(frame); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-stream.disconnect:
+			return
+		case <-n.closed:
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package beaconclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/bsn-eng/pon-golang-types/common"
+)
+
+// FakeBeaconNode is an in-process beacon node serving the endpoints used by Client from the data it is given,
+// so that consumers can test against it without a real beacon node
+type FakeBeaconNode struct {
+	Server *httptest.Server
+
+	mu             sync.RWMutex
+	statusCode     int
+	requests       int
+	genesis        *GenesisData
+	syncStatus     *SyncStatusData
+	validators     []*ValidatorData
+	proposerDuties map[uint64]*GetProposerDutiesResponse
+	randao         map[string]*RandaoData
+	blocks         map[string]*common.VersionedSignedBeaconBlock
+	headers        map[string]*BlockHeaderData
+	withdrawals    map[string]Withdrawals
+	eventStreams   map[*fakeEventStream]bool
+	closed         chan struct{}
+	closeOnce      sync.Once
+}
+
+func NewFakeBeaconNode() *FakeBeaconNode {
+	node := &FakeBeaconNode{
+		proposerDuties: make(map[uint64]*GetProposerDutiesResponse),
+		randao:         make(map[string]*RandaoData),
+		blocks:         make(map[string]*common.VersionedSignedBeaconBlock),
+		headers:        make(map[string]*BlockHeaderData),
+		withdrawals:    make(map[string]Withdrawals),
+		eventStreams:   make(map[*fakeEventStream]bool),
+		closed:         make(chan struct{}),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /eth/v1/beacon/genesis", node.handleGenesis)
+	mux.HandleFunc("GET /eth/v1/node/syncing", node.handleSyncStatus)
+	mux.HandleFunc("GET /eth/v1/beacon/states/{state_id}/validators", node.handleValidators)
+	mux.HandleFunc("GET /eth/v1/validator/duties/proposer/{epoch}", node.handleProposerDuties)
+	mux.HandleFunc("GET /eth/v1/beacon/states/{state_id}/randao", node.handleRandao)
+	mux.HandleFunc("GET /eth/v2/beacon/blocks/{block_id}", node.handleBlock)
+	mux.HandleFunc("GET /eth/v1/beacon/headers/{block_id}", node.handleBlockHeader)
+	mux.HandleFunc("GET /eth/v1/builder/states/{state_id}/expected_withdrawals", node.handleWithdrawals)
+	mux.HandleFunc("GET /eth/v1/events", node.handleEvents)
+
+	node.Server = httptest.NewServer(node.countRequests(mux))
+	return node
+}
+
+func (n *FakeBeaconNode) URL() string {
+	return n.Server.URL
+}
+
+// Close stops the node and ends its event streams, it is safe to call more than once
+func (n *FakeBeaconNode) Close() {
+	n.closeOnce.Do(func() {
+		close(n.closed)
+		n.Server.Close()
+	})
+}
+
+// SetStatusCode makes every request fail with the status code, a zero status code serves requests normally again
+func (n *FakeBeaconNode) SetStatusCode(statusCode int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.statusCode = statusCode
+}
+
+// Requests returns the number of requests received by the node
+func (n *FakeBeaconNode) Requests() int {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.requests
+}
+
+func (n *FakeBeaconNode) SetGenesis(genesis *GenesisData) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.genesis = genesis
+}
+
+func (n *FakeBeaconNode) SetSyncStatus(syncStatus *SyncStatusData) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.syncStatus = syncStatus
+}
+
+// SetValidators sets the validators returned for every state
+func (n *FakeBeaconNode) SetValidators(validators []*ValidatorData) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.validators = validators
+}
+
+func (n *FakeBeaconNode) SetProposerDuties(epoch uint64, duties []*ProposerDutyData) {
+	n.SetProposerDutiesWithDependentRoot(epoch, "", duties)
+}
+
+func (n *FakeBeaconNode) SetProposerDutiesWithDependentRoot(epoch uint64, dependentRoot string, duties []*ProposerDutyData) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.proposerDuties[epoch] = &GetProposerDutiesResponse{
+		DependentRoot: dependentRoot,
+		Data:          duties,
+	}
+}
+
+func (n *FakeBeaconNode) SetRandao(stateID string, randao *RandaoData) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.randao[stateID] = randao
+}
+
+func (n *FakeBeaconNode) SetBlock(blockID string, block *common.VersionedSignedBeaconBlock) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.blocks[blockID] = block
+}
+
+func (n *FakeBeaconNode) SetBlockHeader(blockID string, header *BlockHeaderData) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.headers[blockID] = header
+}
+
+func (n *FakeBeaconNode) SetWithdrawals(stateID string, withdrawals Withdrawals) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.withdrawals[stateID] = withdrawals
+}
+
+func (n *FakeBeaconNode) countRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n.mu.Lock()
+		n.requests++
+		statusCode := n.statusCode
+		n.mu.Unlock()
+
+		if statusCode != 0 {
+			writeError(w, statusCode, "fake beacon node failure")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (n *FakeBeaconNode) handleGenesis(w http.ResponseWriter, r *http.Request) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	if n.genesis == nil {
+		writeError(w, http.StatusNotFound, "chain has not yet reached genesis")
+		return
+	}
+	writeJSON(w, &GetGenesisResponse{Data: n.genesis})
+}
+
+func (n *FakeBeaconNode) handleSyncStatus(w http.ResponseWriter, r *http.Request) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	syncStatus := n.syncStatus
+	if syncStatus == nil {
+		syncStatus = &SyncStatusData{}
+	}
+	writeJSON(w, &GetSyncStatusResponse{Data: syncStatus})
+}
+
+func (n *FakeBeaconNode) handleValidators(w http.ResponseWriter, r *http.Request) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	ids := make(map[string]bool)
+	for _, id := range strings.Split(r.URL.Query().Get("id"), ",") {
+		if id != "" {
+			ids[id] = true
+		}
+	}
+
+	validators := []*ValidatorData{}
+	for _, validator := range n.validators {
+		if len(ids) == 0 || ids[strconv.FormatUint(validator.Index, 10)] || ids[validator.Validator.Pubkey] {
+			validators = append(validators, validator)
+		}
+	}
+	writeJSON(w, &GetValidatorsResponse{Data: validators})
+}
+
+func (n *FakeBeaconNode) handleProposerDuties(w http.ResponseWriter, r *http.Request) {
+	epoch, err := strconv.ParseUint(r.PathValue("epoch"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid epoch")
+		return
+	}
+
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	duties, ok := n.proposerDuties[epoch]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no proposer duties for epoch %d", epoch))
+		return
+	}
+	writeJSON(w, duties)
+}
+
+func (n *FakeBeaconNode) handleRandao(w http.ResponseWriter, r *http.Request) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	randao, ok := n.randao[r.PathValue("state_id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "state not found")
+		return
+	}
+	writeJSON(w, &GetRandaoResponse{Data: randao})
+}
+
+func (n *FakeBeaconNode) handleBlock(w http.ResponseWriter, r *http.Request) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	block, ok := n.blocks[r.PathValue("block_id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "block not found")
+		return
+	}
+
+	version, err := block.Version()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if acceptsSSZ(r.Header.Get("Accept")) {
+		data, err := block.MarshalSSZ()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", MediaTypeSSZ)
+		w.Header().Set(ConsensusVersionHeader, version)
+		w.Write(data)
+		return
+	}
+
+	w.Header().Set(ConsensusVersionHeader, version)
+	writeJSON(w, &GetBlockResponse{
+		Version: version,
+		Data:    block,
+	})
+}
+
+func (n *FakeBeaconNode) handleBlockHeader(w http.ResponseWriter, r *http.Request) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	header, ok := n.headers[r.PathValue("block_id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "block not found")
+		return
+	}
+	writeJSON(w, &GetBlockHeaderResponse{Data: header})
+}
+
+func (n *FakeBeaconNode) handleWithdrawals(w http.ResponseWriter, r *http.Request) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	withdrawals, ok := n.withdrawals[r.PathValue("state_id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "state not found")
+		return
+	}
+	writeJSON(w, &GetWithdrawalsResponse{Data: &withdrawals})
+}
+
+// acceptsSSZ reports whether SSZ is the most preferred media type of the Accept header
+func acceptsSSZ(accept string) bool {
+	bestType, bestQuality := "", -1.0
+	for _, part := range strings.Split(accept, ",") {
+		fields := strings.Split(part, ";")
+		mediaType := strings.TrimSpace(fields[0])
+		quality := 1.0
+		for _, param := range fields[1:] {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if q, err := strconv.ParseFloat(value, 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		if quality > bestQuality {
+			bestType, bestQuality = mediaType, quality
+		}
+	}
+	return bestType == MediaTypeSSZ
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", MediaTypeJSON)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", MediaTypeJSON)
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"code":    statusCode,
+		"message": message,
+	})
+}
@@ -0,0 +1,331 @@
+package beaconclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+
+	commonTypes "github.com/bsn-eng/pon-golang-types/common"
+)
+
+func testElectraBlock(t *testing.T) *commonTypes.VersionedSignedBeaconBlock {
+	t.Helper()
+	return &commonTypes.VersionedSignedBeaconBlock{
+		Electra: &electra.SignedBeaconBlock{
+			Message: &electra.BeaconBlock{
+				Slot:          100,
+				ProposerIndex: 7,
+				ParentRoot:    phase0.Root{0x01},
+				StateRoot:     phase0.Root{0x02},
+				Body: &electra.BeaconBlockBody{
+					ETH1Data: &phase0.ETH1Data{
+						DepositRoot: phase0.Root{0x03},
+						BlockHash:   make([]byte, 32),
+					},
+					ProposerSlashings: []*phase0.ProposerSlashing{},
+					AttesterSlashings: []*electra.AttesterSlashing{},
+					Attestations:      []*electra.Attestation{},
+					Deposits:          []*phase0.Deposit{},
+					VoluntaryExits:    []*phase0.SignedVoluntaryExit{},
+					SyncAggregate: &altair.SyncAggregate{
+						SyncCommitteeBits: make([]byte, 64),
+					},
+					ExecutionPayload: &deneb.ExecutionPayload{
+						BlockNumber:   10,
+						GasLimit:      30000000,
+						Timestamp:     1700000000,
+						ExtraData:     []byte{0x04},
+						BaseFeePerGas: uint256.NewInt(7),
+						BlockHash:     phase0.Hash32{0x05},
+						Transactions:  []bellatrix.Transaction{{0x06}},
+						Withdrawals:   []*capella.Withdrawal{{Index: 1, ValidatorIndex: 2, Amount: 3}},
+						BlobGasUsed:   131072,
+					},
+					BLSToExecutionChanges: []*capella.SignedBLSToExecutionChange{},
+					BlobKZGCommitments:    []deneb.KZGCommitment{{0x07}},
+					ExecutionRequests: &electra.ExecutionRequests{
+						Deposits:       []*electra.DepositRequest{},
+						Withdrawals:    []*electra.WithdrawalRequest{{SourceAddress: bellatrix.ExecutionAddress{0x08}, Amount: 9}},
+						Consolidations: []*electra.ConsolidationRequest{},
+					},
+				},
+			},
+		},
+	}
+}
+
+func testClient(t *testing.T, opts ClientOpts) *Client {
+	t.Helper()
+	client, err := NewClient(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestFakeBeaconNodeCloseTwice(t *testing.T) {
+	node := NewFakeBeaconNode()
+	node.Close()
+	node.Close()
+}
+
+func TestClientBlock(t *testing.T) {
+	node := NewFakeBeaconNode()
+	defer node.Close()
+	block := testElectraBlock(t)
+	node.SetBlock("head", block)
+
+	expected, err := block.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The block is served as JSON by default and as SSZ when the client prefers it
+	for _, preferSSZ := range []bool{false, true} {
+		client := testClient(t, ClientOpts{Endpoints: []string{node.URL()}, PreferSSZ: preferSSZ})
+		res, err := client.Block(context.Background(), "head")
+		if err != nil {
+			t.Fatalf("prefer ssz %t: %v", preferSSZ, err)
+		}
+		if res.Version != "electra" || res.Data.Electra == nil {
+			t.Fatalf("prefer ssz %t: expected an electra block, got version %q", preferSSZ, res.Version)
+		}
+		root, err := res.Data.HashTreeRoot()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if root != expected {
+			t.Fatalf("prefer ssz %t: expected block root %#x, got %#x", preferSSZ, expected, root)
+		}
+	}
+
+	client := testClient(t, ClientOpts{Endpoints: []string{node.URL()}})
+	var apiErr *APIError
+	if _, err := client.Block(context.Background(), "finalized"); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestFakeBeaconNodeBlockHeaders(t *testing.T) {
+	node := NewFakeBeaconNode()
+	defer node.Close()
+	node.SetBlock("head", testElectraBlock(t))
+
+	for _, accept := range []string{MediaTypeJSON, MediaTypeSSZ} {
+		req, err := http.NewRequest(http.MethodGet, node.URL()+"/eth/v2/beacon/blocks/head", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Accept", accept)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if contentType := resp.Header.Get("Content-Type"); contentType != accept {
+			t.Fatalf("expected content type %s, got %s", accept, contentType)
+		}
+		if version := resp.Header.Get(ConsensusVersionHeader); version != "electra" {
+			t.Fatalf("%s: expected consensus version electra, got %q", accept, version)
+		}
+	}
+}
+
+func TestAcceptsSSZ(t *testing.T) {
+	tests := []struct {
+		accept   string
+		expected bool
+	}{
+		{"", false},
+		{MediaTypeJSON, false},
+		{MediaTypeSSZ, true},
+		{"application/octet-stream;q=1.0,application/json;q=0.9", true},
+		{"application/octet-stream;q=0.5, application/json;q=0.9", false},
+		{"application/json;q=0.5, application/octet-stream", true},
+		{"application/json, application/octet-stream", false},
+	}
+	for _, test := range tests {
+		if actual := acceptsSSZ(test.accept); actual != test.expected {
+			t.Errorf("%q: expected %t, got %t", test.accept, test.expected, actual)
+		}
+	}
+}
+
+func TestClientValidators(t *testing.T) {
+	node := NewFakeBeaconNode()
+	defer node.Close()
+	node.SetValidators([]*ValidatorData{
+		{Index: 1, Validator: ValidatorDetails{Pubkey: "0x01"}},
+		{Index: 2, Validator: ValidatorDetails{Pubkey: "0x02"}},
+		{Index: 3, Validator: ValidatorDetails{Pubkey: "0x03"}},
+	})
+	client := testClient(t, ClientOpts{Endpoints: []string{node.URL()}})
+
+	validators, err := client.Validators(context.Background(), "head", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(validators) != 3 {
+		t.Fatalf("expected all 3 validators, got %d", len(validators))
+	}
+
+	// Validators are filtered by index and by public key
+	validators, err = client.Validators(context.Background(), "head", []string{"1", "0x03"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(validators) != 2 || validators[0].Index != 1 || validators[1].Index != 3 {
+		t.Fatalf("expected validators 1 and 3, got %d validators", len(validators))
+	}
+}
+
+func TestClientProposerDuties(t *testing.T) {
+	node := NewFakeBeaconNode()
+	defer node.Close()
+	node.SetProposerDuties(2, []*ProposerDutyData{{PubkeyHex: "0x01", Slot: 64, Index: 5}})
+	client := testClient(t, ClientOpts{Endpoints: []string{node.URL()}})
+
+	duties, err := client.ProposerDuties(context.Background(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(duties) != 1 || duties[0].Slot != 64 || duties[0].Index != 5 || duties[0].PubkeyHex != "0x01" {
+		t.Fatalf("unexpected proposer duties %+v", duties)
+	}
+
+	var apiErr *APIError
+	if _, err := client.ProposerDuties(context.Background(), 3); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestClientRandao(t *testing.T) {
+	node := NewFakeBeaconNode()
+	defer node.Close()
+	node.SetRandao("head", &RandaoData{Randao: common.Hash{0x01}})
+	client := testClient(t, ClientOpts{Endpoints: []string{node.URL()}})
+
+	randao, err := client.Randao(context.Background(), "head")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if randao.Randao != (common.Hash{0x01}) {
+		t.Fatalf("unexpected randao %s", randao.Randao)
+	}
+}
+
+func TestClientBlockHeader(t *testing.T) {
+	node := NewFakeBeaconNode()
+	defer node.Close()
+	node.SetBlockHeader("head", &BlockHeaderData{
+		Root:      "0x01",
+		Canonical: true,
+		Header: &SignedBeaconBlockHeader{
+			Message: &BeaconBlockHeader{Slot: 100, ProposerIndex: 7},
+		},
+	})
+	client := testClient(t, ClientOpts{Endpoints: []string{node.URL()}})
+
+	header, err := client.BlockHeader(context.Background(), "head")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Root != "0x01" || !header.Canonical || header.Header.Message.Slot != 100 || header.Header.Message.ProposerIndex != 7 {
+		t.Fatalf("unexpected block header %+v", header)
+	}
+}
+
+func TestClientExpectedWithdrawals(t *testing.T) {
+	node := NewFakeBeaconNode()
+	defer node.Close()
+	node.SetWithdrawals("head", Withdrawals{{Index: 1, ValidatorIndex: 2, Address: "0x03", Amount: 4}})
+	client := testClient(t, ClientOpts{Endpoints: []string{node.URL()}})
+
+	withdrawals, err := client.ExpectedWithdrawals(context.Background(), "head")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(withdrawals) != 1 || withdrawals[0] != (Withdrawal{Index: 1, ValidatorIndex: 2, Address: "0x03", Amount: 4}) {
+		t.Fatalf("unexpected withdrawals %+v", withdrawals)
+	}
+}
+
+func TestClientFallsBackToNextNode(t *testing.T) {
+	failing := NewFakeBeaconNode()
+	defer failing.Close()
+	failing.SetStatusCode(http.StatusInternalServerError)
+
+	node := NewFakeBeaconNode()
+	defer node.Close()
+	node.SetGenesis(&GenesisData{GenesisTime: 1606824023, GenesisForkVersion: "0x00000000"})
+
+	client := testClient(t, ClientOpts{Endpoints: []string{failing.URL(), node.URL()}})
+	genesis, err := client.Genesis(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if genesis.GenesisTime != 1606824023 {
+		t.Fatalf("unexpected genesis time %d", genesis.GenesisTime)
+	}
+	if failing.Requests() != 1 || node.Requests() != 1 {
+		t.Fatalf("expected one request to each node, got %d and %d", failing.Requests(), node.Requests())
+	}
+}
+
+func TestClientDoesNotFallBackOnClientErrors(t *testing.T) {
+	failing := NewFakeBeaconNode()
+	defer failing.Close()
+	failing.SetStatusCode(http.StatusBadRequest)
+
+	node := NewFakeBeaconNode()
+	defer node.Close()
+
+	client := testClient(t, ClientOpts{Endpoints: []string{failing.URL(), node.URL()}})
+	var apiErr *APIError
+	if _, err := client.Genesis(context.Background()); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected a bad request error, got %v", err)
+	}
+	if node.Requests() != 0 {
+		t.Fatalf("expected no request to the next node, got %d", node.Requests())
+	}
+}
+
+func TestClientReturnsAPIError(t *testing.T) {
+	node := NewFakeBeaconNode()
+	defer node.Close()
+	node.SetStatusCode(http.StatusServiceUnavailable)
+
+	client := testClient(t, ClientOpts{Endpoints: []string{node.URL()}})
+	_, err := client.Genesis(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an api error, got %v", err)
+	}
+}
+
+func TestClientContextCancelled(t *testing.T) {
+	node := NewFakeBeaconNode()
+	defer node.Close()
+	node.SetGenesis(&GenesisData{GenesisTime: 1606824023})
+
+	client := testClient(t, ClientOpts{Endpoints: []string{node.URL(), node.URL()}})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.Genesis(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a cancelled context error, got %v", err)
+	}
+	if node.Requests() != 0 {
+		t.Fatalf("expected no requests, got %d", node.Requests())
+	}
+}
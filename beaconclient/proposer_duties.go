@@ -0,0 +1,209 @@
+package beaconclient
+
+import (
+	"context"
+	"sync"
+)
+
+// ProposerDutiesProvider fetches the proposer duties of an epoch with their dependent root, implemented by Client
+type ProposerDutiesProvider interface {
+	ProposerDutiesWithDependentRoot(ctx context.Context, epoch uint64) ([]*ProposerDutyData, string, error)
+}
+
+// ProposerDutiesCache holds the proposer duties of the current and next epoch, refreshing them when
+// the head moves into a new epoch or a reorg changes the block the duties depend on
+type ProposerDutiesCache struct {
+	provider      ProposerDutiesProvider
+	slotsPerEpoch uint64
+
+	// refreshMu serializes refreshes so a slow fetch cannot overwrite the result of a later one
+	refreshMu sync.Mutex
+
+	mu           sync.RWMutex
+	currentEpoch uint64
+	epochs       map[uint64]*epochProposerDuties
+}
+
+type epochProposerDuties struct {
+	dependentRoot string
+	bySlot        map[uint64]*ProposerDutyData
+}
+
+func NewProposerDutiesCache(provider ProposerDutiesProvider, slotsPerEpoch uint64) *ProposerDutiesCache {
+	if slotsPerEpoch == 0 {
+		slotsPerEpoch = DefaultSlotsPerEpoch
+	}
+	return &ProposerDutiesCache{
+		provider:      provider,
+		slotsPerEpoch: slotsPerEpoch,
+		epochs:        make(map[uint64]*epochProposerDuties),
+	}
+}
+
+// Refresh fetches the duties of the epoch and the next epoch, and drops the duties of earlier epochs.
+// The cache is left unchanged if either fetch fails, or if the epoch is before the cached current epoch
+func (c *ProposerDutiesCache) Refresh(ctx context.Context, epoch uint64) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	if c.isOlder(epoch) {
+		return nil
+	}
+
+	current, err := c.fetch(ctx, epoch)
+	if err != nil {
+		return err
+	}
+	next, err := c.fetch(ctx, epoch+1)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.epochs) > 0 && epoch < c.currentEpoch {
+		return nil
+	}
+	c.currentEpoch = epoch
+	c.epochs = map[uint64]*epochProposerDuties{
+		epoch:     current,
+		epoch + 1: next,
+	}
+	return nil
+}
+
+// refreshNext fetches the duties of the epoch after the epoch, if the epoch is still the cached current epoch
+func (c *ProposerDutiesCache) refreshNext(ctx context.Context, epoch uint64) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	if !c.isCurrent(epoch) {
+		return nil
+	}
+	next, err := c.fetch(ctx, epoch+1)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.epochs) > 0 && epoch == c.currentEpoch {
+		c.epochs[epoch+1] = next
+	}
+	return nil
+}
+
+func (c *ProposerDutiesCache) isOlder(epoch uint64) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.epochs) > 0 && epoch < c.currentEpoch
+}
+
+func (c *ProposerDutiesCache) isCurrent(epoch uint64) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.epochs) > 0 && epoch == c.currentEpoch
+}
+
+// Proposer returns the duty of the validator proposing the slot, if the slot is in a cached epoch
+func (c *ProposerDutiesCache) Proposer(slot uint64) (*ProposerDutyData, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	duties, ok := c.epochs[slot/c.slotsPerEpoch]
+	if !ok {
+		return nil, false
+	}
+	duty, ok := duties.bySlot[slot]
+	return duty, ok
+}
+
+// Duties returns the proposer duties of the epoch ordered by slot, if the epoch is cached
+func (c *ProposerDutiesCache) Duties(epoch uint64) ([]*ProposerDutyData, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	duties, ok := c.epochs[epoch]
+	if !ok {
+		return nil, false
+	}
+	res := []*ProposerDutyData{}
+	for slot := epoch * c.slotsPerEpoch; slot < (epoch+1)*c.slotsPerEpoch; slot++ {
+		if duty, ok := duties.bySlot[slot]; ok {
+			res = append(res, duty)
+		}
+	}
+	return res, true
+}
+
+// DependentRoot returns the dependent root the cached duties of the epoch were computed from
+func (c *ProposerDutiesCache) DependentRoot(epoch uint64) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	duties, ok := c.epochs[epoch]
+	if !ok {
+		return "", false
+	}
+	return duties.dependentRoot, true
+}
+
+// CurrentEpoch returns the epoch of the last refresh
+func (c *ProposerDutiesCache) CurrentEpoch() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.currentEpoch
+}
+
+// HandleHeadEvent refreshes the duties when the head is in an epoch other than the cached current epoch,
+// or when the current duty dependent root of the head differs from the one of the cached duties.
+// The duties of the next epoch depend on the last block of the current epoch, so they are fetched again
+// when the head at the last slot of the epoch is not the block they were computed from
+func (c *ProposerDutiesCache) HandleHeadEvent(ctx context.Context, event *HeadEvent) error {
+	if event == nil || event.Data == nil {
+		return nil
+	}
+	epoch := event.Data.Slot / c.slotsPerEpoch
+
+	c.mu.RLock()
+	duties, cached := c.epochs[epoch]
+	stale := !cached || epoch != c.currentEpoch ||
+		(event.Data.CurrentDutyDependentRoot != "" && event.Data.CurrentDutyDependentRoot != duties.dependentRoot)
+	next, nextCached := c.epochs[epoch+1]
+	nextStale := event.Data.Slot%c.slotsPerEpoch == c.slotsPerEpoch-1 &&
+		(!nextCached || (event.Data.Block != "" && event.Data.Block != next.dependentRoot))
+	c.mu.RUnlock()
+
+	if stale {
+		return c.Refresh(ctx, epoch)
+	}
+	if nextStale {
+		return c.refreshNext(ctx, epoch)
+	}
+	return nil
+}
+
+// HandleChainReorgEvent refreshes the duties of the epoch of the new head, as the reorg may have
+// replaced the block the duties depend on. A reorg to a head before the cached current epoch is ignored
+func (c *ProposerDutiesCache) HandleChainReorgEvent(ctx context.Context, event *ChainReorgEvent) error {
+	if event == nil || event.Data == nil {
+		return nil
+	}
+	return c.Refresh(ctx, event.Data.Slot/c.slotsPerEpoch)
+}
+
+func (c *ProposerDutiesCache) fetch(ctx context.Context, epoch uint64) (*epochProposerDuties, error) {
+	duties, dependentRoot, err := c.provider.ProposerDutiesWithDependentRoot(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &epochProposerDuties{
+		dependentRoot: dependentRoot,
+		bySlot:        make(map[uint64]*ProposerDutyData, len(duties)),
+	}
+	for _, duty := range duties {
+		res.bySlot[duty.Slot] = duty
+	}
+	return res, nil
+}
@@ -0,0 +1,146 @@
+package beaconclient
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+// blockingDutiesProvider returns duties with the epoch as dependent root, holding fetches of the blocked epoch
+// until released
+type blockingDutiesProvider struct {
+	blocked uint64
+	started chan struct{}
+	release chan struct{}
+}
+
+func (p *blockingDutiesProvider) ProposerDutiesWithDependentRoot(ctx context.Context, epoch uint64) ([]*ProposerDutyData, string, error) {
+	if epoch == p.blocked {
+		close(p.started)
+		<-p.release
+	}
+	return []*ProposerDutyData{{Slot: epoch * 32, Index: epoch}}, string(rune('a' + epoch)), nil
+}
+
+func testProposerDutiesCache(t *testing.T, node *FakeBeaconNode) *ProposerDutiesCache {
+	t.Helper()
+	client, err := NewClient(ClientOpts{Endpoints: []string{node.URL()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewProposerDutiesCache(client, 32)
+}
+
+func TestProposerDutiesRefreshIgnoresOlderEpoch(t *testing.T) {
+	cache := NewProposerDutiesCache(&blockingDutiesProvider{blocked: 100}, 32)
+	if err := cache.Refresh(context.Background(), 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Refresh(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	if cache.CurrentEpoch() != 2 {
+		t.Fatalf("expected current epoch 2, got %d", cache.CurrentEpoch())
+	}
+	if _, ok := cache.Duties(1); ok {
+		t.Fatal("older epoch was stored")
+	}
+}
+
+func TestProposerDutiesConcurrentRefresh(t *testing.T) {
+	provider := &blockingDutiesProvider{blocked: 1, started: make(chan struct{}), release: make(chan struct{})}
+	cache := NewProposerDutiesCache(provider, 32)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if err := cache.Refresh(context.Background(), 1); err != nil {
+			t.Error(err)
+		}
+	}()
+	<-provider.started
+	go func() {
+		defer wg.Done()
+		if err := cache.Refresh(context.Background(), 2); err != nil {
+			t.Error(err)
+		}
+	}()
+	close(provider.release)
+	wg.Wait()
+
+	if cache.CurrentEpoch() != 2 {
+		t.Fatalf("expected current epoch 2, got %d", cache.CurrentEpoch())
+	}
+	if root, _ := cache.DependentRoot(3); root != "d" {
+		t.Fatalf("expected duties of epoch 3, got dependent root %q", root)
+	}
+}
+
+func TestProposerDutiesHeadEventRefreshesNextEpoch(t *testing.T) {
+	node := NewFakeBeaconNode()
+	defer node.Close()
+	node.SetProposerDutiesWithDependentRoot(0, "0x0a", []*ProposerDutyData{{Slot: 0, Index: 1}})
+	node.SetProposerDutiesWithDependentRoot(1, "0x0b", []*ProposerDutyData{{Slot: 32, Index: 2}})
+
+	cache := testProposerDutiesCache(t, node)
+	if err := cache.Refresh(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	node.SetProposerDutiesWithDependentRoot(1, "0x0c", []*ProposerDutyData{{Slot: 32, Index: 3}})
+
+	// A head before the last slot of the epoch does not decide the next epoch duties
+	requests := node.Requests()
+	event := &HeadEvent{Data: &HeadEventData{Slot: 5, Block: "0x0d", CurrentDutyDependentRoot: "0x0a"}}
+	if err := cache.HandleHeadEvent(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+	if node.Requests() != requests {
+		t.Fatal("duties fetched for a head before the last slot")
+	}
+
+	event = &HeadEvent{Data: &HeadEventData{Slot: 31, Block: "0x0c", CurrentDutyDependentRoot: "0x0a"}}
+	if err := cache.HandleHeadEvent(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+	if root, _ := cache.DependentRoot(1); root != "0x0c" {
+		t.Fatalf("expected next epoch dependent root 0x0c, got %s", root)
+	}
+	if duty, ok := cache.Proposer(32); !ok || duty.Index != 3 {
+		t.Fatal("next epoch duties not refreshed")
+	}
+	if root, _ := cache.DependentRoot(0); root != "0x0a" {
+		t.Fatalf("current epoch duties changed, got dependent root %s", root)
+	}
+
+	// The next epoch duties are not fetched again once they match the head
+	requests = node.Requests()
+	if err := cache.HandleHeadEvent(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+	if node.Requests() != requests {
+		t.Fatal("duties fetched again for the same head")
+	}
+}
+
+func TestProposerDutiesHeadEventDependentRootChange(t *testing.T) {
+	node := NewFakeBeaconNode()
+	defer node.Close()
+	node.SetProposerDutiesWithDependentRoot(0, "0x0a", []*ProposerDutyData{{Slot: 0, Index: 1}})
+	node.SetProposerDutiesWithDependentRoot(1, "0x0b", []*ProposerDutyData{{Slot: 32, Index: 2}})
+
+	cache := testProposerDutiesCache(t, node)
+	if err := cache.Refresh(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	node.SetProposerDutiesWithDependentRoot(0, "0x0e", []*ProposerDutyData{{Slot: 0, Index: 4}})
+	event := &HeadEvent{Data: &HeadEventData{Slot: 3, Block: "0x0f", CurrentDutyDependentRoot: "0x0e"}}
+	if err := cache.HandleHeadEvent(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+	if duty, ok := cache.Proposer(0); !ok || duty.Index != 4 {
+		t.Fatal("duties not refreshed after the dependent root changed")
+	}
+}
@@ -1,6 +1,8 @@
 package beaconclient
 
 import (
+	"encoding/json"
+
 	"github.com/bsn-eng/pon-golang-types/common"
 )
 
@@ -13,7 +15,7 @@ type GetWithdrawalsResponse struct {
 }
 
 type GetSyncStatusResponse struct {
-	Data *SyncStatusData
+	Data *SyncStatusData `json:"data"`
 }
 
 type GetValidatorsResponse struct {
@@ -21,7 +23,8 @@ type GetValidatorsResponse struct {
 }
 
 type GetProposerDutiesResponse struct {
-	Data []*ProposerDutyData `json:"data"`
+	DependentRoot string              `json:"dependent_root"`
+	Data          []*ProposerDutyData `json:"data"`
 }
 
 type GetRandaoResponse struct {
@@ -35,6 +38,40 @@ type GetBlockResponse struct {
 	Data *common.VersionedSignedBeaconBlock `json:"data"`
 }
 
+// UnmarshalJSON decodes the block data using the version returned by the beacon node
+func (r *GetBlockResponse) UnmarshalJSON(input []byte) error {
+	var data struct {
+		Version             string          `json:"version"`
+		ExecutionOptimistic bool            `json:"execution_optimistic"`
+		Finalized           bool            `json:"finalized"`
+		Data                json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(input, &data); err != nil {
+		return err
+	}
+
+	r.Version = data.Version
+	r.ExecutionOptimistic = data.ExecutionOptimistic
+	r.Finalized = data.Finalized
+	r.Data = nil
+	if len(data.Data) == 0 || string(data.Data) == "null" {
+		return nil
+	}
+
+	version, err := common.DataVersionFromName(data.Version)
+	if err != nil {
+		return err
+	}
+
+	block := &common.VersionedSignedBeaconBlock{}
+	if err := block.UnmarshalJSONWithVersion(version, data.Data); err != nil {
+		return err
+	}
+	r.Data = block
+
+	return nil
+}
+
 type GetBlockHeaderResponse struct {
 	Data *BlockHeaderData `json:"data"`
 }
\ No newline at end of file
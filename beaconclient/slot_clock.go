@@ -0,0 +1,153 @@
+package beaconclient
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	DefaultSecondsPerSlot = 12
+	DefaultSlotsPerEpoch  = 32
+)
+
+// TimeSource provides the current time and timers to the SlotClock, so that tests can control time
+type TimeSource interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemTimeSource struct{}
+
+func (systemTimeSource) Now() time.Time {
+	return time.Now()
+}
+
+func (systemTimeSource) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Slot Clock Parameters, zero values use the mainnet defaults and the system clock
+type SlotClockOpts struct {
+	SecondsPerSlot uint64
+	SlotsPerEpoch  uint64
+	TimeSource     TimeSource
+}
+
+// SlotClock converts between wall clock time and slots and epochs using the genesis time of the chain
+type SlotClock struct {
+	genesisTime   time.Time
+	slotDuration  time.Duration
+	slotsPerEpoch uint64
+	timeSource    TimeSource
+}
+
+func NewSlotClock(genesisTime uint64, opts SlotClockOpts) *SlotClock {
+	secondsPerSlot := opts.SecondsPerSlot
+	if secondsPerSlot == 0 {
+		secondsPerSlot = DefaultSecondsPerSlot
+	}
+	slotsPerEpoch := opts.SlotsPerEpoch
+	if slotsPerEpoch == 0 {
+		slotsPerEpoch = DefaultSlotsPerEpoch
+	}
+	timeSource := opts.TimeSource
+	if timeSource == nil {
+		timeSource = systemTimeSource{}
+	}
+
+	return &SlotClock{
+		genesisTime:   time.Unix(int64(genesisTime), 0),
+		slotDuration:  time.Duration(secondsPerSlot) * time.Second,
+		slotsPerEpoch: slotsPerEpoch,
+		timeSource:    timeSource,
+	}
+}
+
+// SlotClock returns a slot clock starting at the genesis time
+func (g *GenesisData) SlotClock(opts SlotClockOpts) *SlotClock {
+	return NewSlotClock(g.GenesisTime, opts)
+}
+
+func (c *SlotClock) GenesisTime() time.Time {
+	return c.genesisTime
+}
+
+func (c *SlotClock) SlotDuration() time.Duration {
+	return c.slotDuration
+}
+
+// CurrentSlot returns the slot at the current time, which is 0 before genesis
+func (c *SlotClock) CurrentSlot() uint64 {
+	return c.SlotAt(c.timeSource.Now())
+}
+
+// SlotAt returns the slot at the time, which is 0 before genesis
+func (c *SlotClock) SlotAt(t time.Time) uint64 {
+	if t.Before(c.genesisTime) {
+		return 0
+	}
+	return uint64(t.Sub(c.genesisTime) / c.slotDuration)
+}
+
+func (c *SlotClock) SlotStartTime(slot uint64) time.Time {
+	return c.genesisTime.Add(time.Duration(slot) * c.slotDuration)
+}
+
+// TimeIntoSlot returns the time elapsed since the start of the current slot, which is negative before genesis
+func (c *SlotClock) TimeIntoSlot() time.Duration {
+	now := c.timeSource.Now()
+	if now.Before(c.genesisTime) {
+		return now.Sub(c.genesisTime)
+	}
+	return now.Sub(c.SlotStartTime(c.SlotAt(now)))
+}
+
+func (c *SlotClock) CurrentEpoch() uint64 {
+	return c.EpochOfSlot(c.CurrentSlot())
+}
+
+func (c *SlotClock) EpochOfSlot(slot uint64) uint64 {
+	return slot / c.slotsPerEpoch
+}
+
+func (c *SlotClock) EpochStartSlot(epoch uint64) uint64 {
+	return epoch * c.slotsPerEpoch
+}
+
+// Ticker delivers each new slot at its start time until the context is done, then closes the channel.
+// The first slot delivered is the slot after the current one, or slot 0 before genesis
+func (c *SlotClock) Ticker(ctx context.Context) <-chan uint64 {
+	ticks := make(chan uint64, 1)
+
+	go func() {
+		defer close(ticks)
+
+		ticked, last := false, uint64(0)
+		for {
+			now := c.timeSource.Now()
+			next := uint64(0)
+			if !now.Before(c.genesisTime) {
+				next = c.SlotAt(now) + 1
+			}
+			// A timer firing marginally early must not deliver the same slot twice
+			if ticked && next <= last {
+				next = last + 1
+			}
+
+			select {
+			case <-c.timeSource.After(c.SlotStartTime(next).Sub(now)):
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case ticks <- next:
+				ticked, last = true, next
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ticks
+}
@@ -0,0 +1,95 @@
+package beaconclient
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeTimeSource is a clock whose timers fire at once, moving the clock to their deadline
+type fakeTimeSource struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (f *fakeTimeSource) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeTimeSource) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if d > 0 {
+		f.now = f.now.Add(d)
+	}
+	res := make(chan time.Time, 1)
+	res <- f.now
+	return res
+}
+
+func TestSlotClock(t *testing.T) {
+	genesis := time.Unix(1606824023, 0)
+	timeSource := &fakeTimeSource{now: genesis.Add(-5 * time.Second)}
+	clock := NewSlotClock(uint64(genesis.Unix()), SlotClockOpts{TimeSource: timeSource})
+
+	if clock.CurrentSlot() != 0 || clock.TimeIntoSlot() != -5*time.Second {
+		t.Fatalf("unexpected slot %d at %s into slot before genesis", clock.CurrentSlot(), clock.TimeIntoSlot())
+	}
+
+	timeSource.now = genesis.Add(65*12*time.Second + 3*time.Second)
+	if clock.CurrentSlot() != 65 || clock.CurrentEpoch() != 2 {
+		t.Fatalf("expected slot 65 in epoch 2, got slot %d in epoch %d", clock.CurrentSlot(), clock.CurrentEpoch())
+	}
+	if clock.TimeIntoSlot() != 3*time.Second {
+		t.Fatalf("expected 3s into the slot, got %s", clock.TimeIntoSlot())
+	}
+	if !clock.SlotStartTime(65).Equal(genesis.Add(65 * 12 * time.Second)) {
+		t.Fatal("unexpected slot start time")
+	}
+	if clock.EpochStartSlot(2) != 64 || clock.EpochOfSlot(63) != 1 {
+		t.Fatal("unexpected epoch boundaries")
+	}
+
+	genesisData := &GenesisData{GenesisTime: uint64(genesis.Unix())}
+	custom := genesisData.SlotClock(SlotClockOpts{SecondsPerSlot: 6, SlotsPerEpoch: 8, TimeSource: timeSource})
+	if custom.CurrentSlot() != 130 || custom.CurrentEpoch() != 16 {
+		t.Fatalf("expected slot 130 in epoch 16, got slot %d in epoch %d", custom.CurrentSlot(), custom.CurrentEpoch())
+	}
+}
+
+func TestSlotClockTicker(t *testing.T) {
+	genesis := time.Unix(1606824023, 0)
+	timeSource := &fakeTimeSource{now: genesis.Add(10*12*time.Second + time.Second)}
+	clock := NewSlotClock(uint64(genesis.Unix()), SlotClockOpts{TimeSource: timeSource})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ticks := clock.Ticker(ctx)
+	for expected := uint64(11); expected < 14; expected++ {
+		slot := <-ticks
+		if slot != expected {
+			t.Fatalf("expected slot %d, got %d", expected, slot)
+		}
+		if timeSource.Now().Before(clock.SlotStartTime(slot)) {
+			t.Fatalf("slot %d delivered before its start", slot)
+		}
+	}
+
+	cancel()
+	for range ticks {
+	}
+}
+
+func TestSlotClockTickerBeforeGenesis(t *testing.T) {
+	genesis := time.Unix(1606824023, 0)
+	timeSource := &fakeTimeSource{now: genesis.Add(-time.Minute)}
+	clock := NewSlotClock(uint64(genesis.Unix()), SlotClockOpts{TimeSource: timeSource})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if slot := <-clock.Ticker(ctx); slot != 0 || timeSource.Now().Before(genesis) {
+		t.Fatalf("expected slot 0 from genesis, got slot %d", slot)
+	}
+}
@@ -0,0 +1,314 @@
+package beaconclient
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	MediaTypeEventStream = "text/event-stream"
+
+	defaultMinBackoff = 500 * time.Millisecond
+	defaultMaxBackoff = 30 * time.Second
+	defaultBufferSize = 16
+)
+
+// SlotGap is a range of slots, inclusive, for which no head event was received
+type SlotGap struct {
+	FromSlot uint64
+	ToSlot   uint64
+}
+
+// Event Subscriber Parameters
+type EventSubscriberOpts struct {
+	// Endpoints are connected to in turn, moving to the next one on every reconnect
+	Endpoints []string
+	// Topics defaults to the head and payload_attributes topics
+	Topics []string
+	// Dispatcher handles the events of topics other than head and payload_attributes
+	Dispatcher *EventDispatcher
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	BufferSize int
+}
+
+// EventSubscriber subscribes to the beacon node event stream and delivers the events on channels,
+// reconnecting with exponential backoff whenever the stream ends. The channels of all subscribed
+// topics must be drained, as delivery blocks until the event is received
+type EventSubscriber struct {
+	endpoints  []string
+	topics     []string
+	dispatcher *EventDispatcher
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	httpClient *http.Client
+
+	headEvents              chan *HeadEvent
+	payloadAttributesEvents chan *PayloadAttributesEvent
+	slotGaps                chan SlotGap
+	errors                  chan error
+
+	running  sync.Once
+	lastSlot uint64
+}
+
+type sseFrame struct {
+	event string
+	data  string
+}
+
+func NewEventSubscriber(opts EventSubscriberOpts) (*EventSubscriber, error) {
+	if len(opts.Endpoints) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	endpoints := make([]string, len(opts.Endpoints))
+	for i, endpoint := range opts.Endpoints {
+		endpoints[i] = strings.TrimSuffix(endpoint, "/")
+	}
+
+	topics := opts.Topics
+	if len(topics) == 0 {
+		topics = []string{TopicHead, TopicPayloadAttributes}
+	}
+	for _, topic := range topics {
+		if !IsEventTopic(topic) {
+			return nil, fmt.Errorf("unsupported event topic %s", topic)
+		}
+		if topic != TopicHead && topic != TopicPayloadAttributes && opts.Dispatcher == nil {
+			return nil, fmt.Errorf("no dispatcher set for event topic %s", topic)
+		}
+	}
+
+	minBackoff := opts.MinBackoff
+	if minBackoff == 0 {
+		minBackoff = defaultMinBackoff
+	}
+	maxBackoff := opts.MaxBackoff
+	if maxBackoff == 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+	if maxBackoff < minBackoff {
+		maxBackoff = minBackoff
+	}
+	bufferSize := opts.BufferSize
+	if bufferSize == 0 {
+		bufferSize = defaultBufferSize
+	}
+
+	return &EventSubscriber{
+		endpoints:               endpoints,
+		topics:                  topics,
+		dispatcher:              opts.Dispatcher,
+		minBackoff:              minBackoff,
+		maxBackoff:              maxBackoff,
+		httpClient:              &http.Client{},
+		headEvents:              make(chan *HeadEvent, bufferSize),
+		payloadAttributesEvents: make(chan *PayloadAttributesEvent, bufferSize),
+		slotGaps:                make(chan SlotGap, bufferSize),
+		errors:                  make(chan error, bufferSize),
+	}, nil
+}
+
+func (s *EventSubscriber) HeadEvents() <-chan *HeadEvent {
+	return s.headEvents
+}
+
+func (s *EventSubscriber) PayloadAttributesEvents() <-chan *PayloadAttributesEvent {
+	return s.payloadAttributesEvents
+}
+
+// SlotGaps delivers the slots skipped between consecutive head events, including across reconnects.
+// Gaps are dropped if the channel is full
+func (s *EventSubscriber) SlotGaps() <-chan SlotGap {
+	return s.slotGaps
+}
+
+// Errors delivers connection and decoding errors. Errors are dropped if the channel is full
+func (s *EventSubscriber) Errors() <-chan error {
+	return s.errors
+}
+
+// Run subscribes to the event stream until the context is done, then closes the event channels.
+// It can only be called once
+func (s *EventSubscriber) Run(ctx context.Context) error {
+	err := errors.New("event subscriber can only be run once")
+	s.running.Do(func() {
+		err = s.run(ctx)
+	})
+	return err
+}
+
+func (s *EventSubscriber) run(ctx context.Context) error {
+	defer func() {
+		close(s.headEvents)
+		close(s.payloadAttributesEvents)
+		close(s.slotGaps)
+		close(s.errors)
+	}()
+
+	backoff := s.minBackoff
+	for attempt := 0; ; attempt++ {
+		endpoint := s.endpoints[attempt%len(s.endpoints)]
+
+		received, err := s.subscribe(ctx, endpoint)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err == nil {
+			err = fmt.Errorf("event stream from %s ended", endpoint)
+		}
+		s.reportError(err)
+
+		if received {
+			backoff = s.minBackoff
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if backoff > s.maxBackoff {
+			backoff = s.maxBackoff
+		}
+	}
+}
+
+// subscribe reads the event stream of the endpoint until it ends, reporting whether any event was received
+func (s *EventSubscriber) subscribe(ctx context.Context, endpoint string) (bool, error) {
+	target := fmt.Sprintf("%s/eth/v1/events?topics=%s", endpoint, strings.Join(s.topics, ","))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", MediaTypeEventStream)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, &APIError{
+			Endpoint:   endpoint,
+			StatusCode: resp.StatusCode,
+			Message:    http.StatusText(resp.StatusCode),
+		}
+	}
+
+	received := false
+	reader := bufio.NewReader(resp.Body)
+	for {
+		frame, err := readSSEFrame(reader)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return received, nil
+			}
+			return received, err
+		}
+		if frame == nil {
+			continue
+		}
+		if err := s.dispatch(ctx, frame); err != nil {
+			s.reportError(err)
+			continue
+		}
+		received = true
+	}
+}
+
+func (s *EventSubscriber) dispatch(ctx context.Context, frame *sseFrame) error {
+	switch frame.event {
+	case TopicHead:
+		decoded, err := DecodeEvent(frame.event, []byte(frame.data))
+		if err != nil {
+			return err
+		}
+		event := decoded.(*HeadEvent)
+		s.detectSlotGap(event.Data.Slot)
+		select {
+		case s.headEvents <- event:
+		case <-ctx.Done():
+		}
+	case TopicPayloadAttributes:
+		decoded, err := DecodeEvent(frame.event, []byte(frame.data))
+		if err != nil {
+			return err
+		}
+		select {
+		case s.payloadAttributesEvents <- decoded.(*PayloadAttributesEvent):
+		case <-ctx.Done():
+		}
+	default:
+		if s.dispatcher == nil {
+			return nil
+		}
+		return s.dispatcher.Dispatch(frame.event, []byte(frame.data))
+	}
+	return nil
+}
+
+func (s *EventSubscriber) detectSlotGap(slot uint64) {
+	if s.lastSlot != 0 && slot > s.lastSlot+1 {
+		select {
+		case s.slotGaps <- SlotGap{FromSlot: s.lastSlot + 1, ToSlot: slot - 1}:
+		default:
+		}
+	}
+	if slot > s.lastSlot {
+		s.lastSlot = slot
+	}
+}
+
+func (s *EventSubscriber) reportError(err error) {
+	select {
+	case s.errors <- err:
+	default:
+	}
+}
+
+// readSSEFrame reads lines up to the next blank line, returning a nil frame for frames without data
+func readSSEFrame(reader *bufio.Reader) (*sseFrame, error) {
+	frame := &sseFrame{}
+	var data []string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "" {
+			if len(data) == 0 {
+				return nil, nil
+			}
+			frame.data = strings.Join(data, "\n")
+			return frame, nil
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			frame.event = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package beaconclient
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testWaitForEventStreams(t *testing.T, node *FakeBeaconNode, streams int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for node.EventStreams() != streams {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d event streams, got %d", streams, node.EventStreams())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func testReceiveHeadEvent(t *testing.T, subscriber *EventSubscriber) *HeadEvent {
+	t.Helper()
+	select {
+	case event := <-subscriber.HeadEvents():
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no head event received")
+		return nil
+	}
+}
+
+func TestEventSubscriberReconnects(t *testing.T) {
+	failing := NewFakeBeaconNode()
+	defer failing.Close()
+	failing.SetStatusCode(http.StatusServiceUnavailable)
+	node := NewFakeBeaconNode()
+	defer node.Close()
+
+	subscriber, err := NewEventSubscriber(EventSubscriberOpts{
+		Endpoints:  []string{failing.URL(), node.URL()},
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- subscriber.Run(ctx)
+	}()
+
+	testWaitForEventStreams(t, node, 1)
+	if err := node.PublishHeadEvent(&HeadEventData{Slot: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if event := testReceiveHeadEvent(t, subscriber); event.Data.Slot != 1 {
+		t.Fatalf("expected slot 1, got %d", event.Data.Slot)
+	}
+
+	// The slots missed while reconnecting are reported as a gap
+	node.DisconnectEventStreams()
+	testWaitForEventStreams(t, node, 1)
+	if err := node.PublishHeadEvent(&HeadEventData{Slot: 4}); err != nil {
+		t.Fatal(err)
+	}
+	if event := testReceiveHeadEvent(t, subscriber); event.Data.Slot != 4 {
+		t.Fatalf("expected slot 4, got %d", event.Data.Slot)
+	}
+	select {
+	case gap := <-subscriber.SlotGaps():
+		if gap != (SlotGap{FromSlot: 2, ToSlot: 3}) {
+			t.Fatalf("unexpected slot gap %+v", gap)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no slot gap reported")
+	}
+
+	var apiErr *APIError
+	for err := range subscriber.Errors() {
+		if errors.As(err, &apiErr) {
+			break
+		}
+	}
+	if apiErr == nil || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected the failing node error, got %v", apiErr)
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a canceled context, got %v", err)
+	}
+	if _, ok := <-subscriber.HeadEvents(); ok {
+		t.Fatal("head events not closed")
+	}
+	if err := subscriber.Run(context.Background()); err == nil {
+		t.Fatal("expected an error running twice")
+	}
+}
+
+func TestEventSubscriberPayloadAttributes(t *testing.T) {
+	node := NewFakeBeaconNode()
+	defer node.Close()
+
+	subscriber, err := NewEventSubscriber(EventSubscriberOpts{Endpoints: []string{node.URL()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go subscriber.Run(ctx)
+
+	testWaitForEventStreams(t, node, 1)
+	err = node.PublishPayloadAttributesEvent(&PayloadAttributesEvent{
+		Version: "deneb",
+		Data:    &PayloadAttributesEventData{ProposalSlot: 10, ParentBlockNumber: 99},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case event := <-subscriber.PayloadAttributesEvents():
+		if event.Version != "deneb" || event.Data.ProposalSlot != 10 || event.Data.ParentBlockNumber != 99 {
+			t.Fatalf("unexpected payload attributes event %+v", event.Data)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no payload attributes event received")
+	}
+}
+
+func TestNewEventSubscriberErrors(t *testing.T) {
+	if _, err := NewEventSubscriber(EventSubscriberOpts{}); !errors.Is(err, ErrNoEndpoints) {
+		t.Fatalf("expected no endpoints error, got %v", err)
+	}
+	if _, err := NewEventSubscriber(EventSubscriberOpts{Endpoints: []string{"http://localhost"}, Topics: []string{"unknown"}}); err == nil {
+		t.Fatal("expected an error for an unknown topic")
+	}
+	if _, err := NewEventSubscriber(EventSubscriberOpts{Endpoints: []string{"http://localhost"}, Topics: []string{TopicBlock}}); err == nil {
+		t.Fatal("expected an error for a topic without a dispatcher")
+	}
+}
+
+func TestReadSSEFrame(t *testing.T) {
+	reader := bufio.NewReader(strings.NewReader(": comment\n\nevent: head\r\ndata: {\"a\":\ndata: 1}\n\n"))
+
+	frame, err := readSSEFrame(reader)
+	if err != nil || frame != nil {
+		t.Fatalf("expected an empty frame, got %+v and %v", frame, err)
+	}
+	frame, err = readSSEFrame(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if frame.event != TopicHead || frame.data != "{\"a\":\n1}" {
+		t.Fatalf("unexpected frame %+v", frame)
+	}
+}
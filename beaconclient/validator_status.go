@@ -0,0 +1,105 @@
+package beaconclient
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// FarFutureEpoch is the exit and withdrawable epoch of validators that have not exited
+const FarFutureEpoch = phase0.Epoch(1<<64 - 1)
+
+// ValidatorStatus is the status of a validator as reported by the beacon API
+type ValidatorStatus string
+
+const (
+	ValidatorStatusPendingInitialized ValidatorStatus = "pending_initialized"
+	ValidatorStatusPendingQueued      ValidatorStatus = "pending_queued"
+	ValidatorStatusActiveOngoing      ValidatorStatus = "active_ongoing"
+	ValidatorStatusActiveExiting      ValidatorStatus = "active_exiting"
+	ValidatorStatusActiveSlashed      ValidatorStatus = "active_slashed"
+	ValidatorStatusExitedUnslashed    ValidatorStatus = "exited_unslashed"
+	ValidatorStatusExitedSlashed      ValidatorStatus = "exited_slashed"
+	ValidatorStatusWithdrawalPossible ValidatorStatus = "withdrawal_possible"
+	ValidatorStatusWithdrawalDone     ValidatorStatus = "withdrawal_done"
+)
+
+// Validator status groups, accepted by the beacon API status filter in place of the statuses they contain
+const (
+	ValidatorStatusPending    ValidatorStatus = "pending"
+	ValidatorStatusActive     ValidatorStatus = "active"
+	ValidatorStatusExited     ValidatorStatus = "exited"
+	ValidatorStatusWithdrawal ValidatorStatus = "withdrawal"
+)
+
+var validatorStatuses = map[ValidatorStatus]bool{
+	ValidatorStatusPendingInitialized: true,
+	ValidatorStatusPendingQueued:      true,
+	ValidatorStatusActiveOngoing:      true,
+	ValidatorStatusActiveExiting:      true,
+	ValidatorStatusActiveSlashed:      true,
+	ValidatorStatusExitedUnslashed:    true,
+	ValidatorStatusExitedSlashed:      true,
+	ValidatorStatusWithdrawalPossible: true,
+	ValidatorStatusWithdrawalDone:     true,
+}
+
+// ParseValidatorStatus returns the status with the name, which must not be a status group
+func ParseValidatorStatus(name string) (ValidatorStatus, error) {
+	status := ValidatorStatus(name)
+	if !status.IsKnown() {
+		return "", fmt.Errorf("unknown validator status %s", name)
+	}
+	return status, nil
+}
+
+func (s ValidatorStatus) String() string {
+	return string(s)
+}
+
+// IsKnown reports whether the status is one of the beacon API statuses
+func (s ValidatorStatus) IsKnown() bool {
+	return validatorStatuses[s]
+}
+
+// Matches reports whether the status is the filter status, or is in the filter status group
+func (s ValidatorStatus) Matches(filter ValidatorStatus) bool {
+	return s == filter || strings.HasPrefix(string(s), string(filter)+"_")
+}
+
+func (s ValidatorStatus) IsPending() bool {
+	return s.Matches(ValidatorStatusPending)
+}
+
+// IsActive reports whether the validator is active, including validators that are exiting or slashed
+// but still have to perform duties
+func (s ValidatorStatus) IsActive() bool {
+	return s.Matches(ValidatorStatusActive)
+}
+
+// IsExited reports whether the validator has exited, including validators that can withdraw or have withdrawn
+func (s ValidatorStatus) IsExited() bool {
+	return s.Matches(ValidatorStatusExited) || s.Matches(ValidatorStatusWithdrawal)
+}
+
+// IsSlashed reports whether the status shows the validator as slashed. The withdrawal statuses do not tell
+// slashed validators apart, use ValidatorData.IsSlashed to also check the validator record
+func (s ValidatorStatus) IsSlashed() bool {
+	return s == ValidatorStatusActiveSlashed || s == ValidatorStatusExitedSlashed
+}
+
+// IsSlashed reports whether the validator has been slashed according to its status or its record
+func (v *ValidatorData) IsSlashed() bool {
+	return v.Status.IsSlashed() || v.Validator.Slashed
+}
+
+// IsActiveAtEpoch reports whether the validator is active at the epoch according to its record
+func (v *ValidatorDetails) IsActiveAtEpoch(epoch phase0.Epoch) bool {
+	return v.ActivationEpoch <= epoch && epoch < v.ExitEpoch
+}
+
+// HasExited reports whether the validator has an exit epoch at or before the epoch
+func (v *ValidatorDetails) HasExited(epoch phase0.Epoch) bool {
+	return v.ExitEpoch != FarFutureEpoch && v.ExitEpoch <= epoch
+}
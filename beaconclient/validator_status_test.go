@@ -0,0 +1,75 @@
+package beaconclient
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+func TestValidatorStatus(t *testing.T) {
+	tests := []struct {
+		status                           ValidatorStatus
+		pending, active, exited, slashed bool
+	}{
+		{ValidatorStatusPendingInitialized, true, false, false, false},
+		{ValidatorStatusPendingQueued, true, false, false, false},
+		{ValidatorStatusActiveOngoing, false, true, false, false},
+		{ValidatorStatusActiveExiting, false, true, false, false},
+		{ValidatorStatusActiveSlashed, false, true, false, true},
+		{ValidatorStatusExitedUnslashed, false, false, true, false},
+		{ValidatorStatusExitedSlashed, false, false, true, true},
+		{ValidatorStatusWithdrawalPossible, false, false, true, false},
+		{ValidatorStatusWithdrawalDone, false, false, true, false},
+	}
+	for _, test := range tests {
+		status, err := ParseValidatorStatus(test.status.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status.IsPending() != test.pending || status.IsActive() != test.active ||
+			status.IsExited() != test.exited || status.IsSlashed() != test.slashed {
+			t.Fatalf("unexpected groups for %s", status)
+		}
+	}
+
+	for _, name := range []string{"active", "unknown", "active_"} {
+		if _, err := ParseValidatorStatus(name); err == nil {
+			t.Fatalf("expected an error parsing %s", name)
+		}
+	}
+	if ValidatorStatusActiveOngoing.Matches("act") {
+		t.Fatal("status matched a partial group name")
+	}
+}
+
+func TestValidatorData(t *testing.T) {
+	data := `{"index":"7","balance":"32000000000","status":"withdrawal_done","validator":{` +
+		`"pubkey":"0x01","withdrawal_credentials":"0x02","effective_balance":"0","slashed":true,` +
+		`"activation_eligibility_epoch":"1","activation_epoch":"2","exit_epoch":"10","withdrawable_epoch":"20"}}`
+	validator := &ValidatorData{}
+	if err := json.Unmarshal([]byte(data), validator); err != nil {
+		t.Fatal(err)
+	}
+	if validator.Index != 7 || validator.Balance != 32000000000 || validator.Status != ValidatorStatusWithdrawalDone {
+		t.Fatalf("unexpected validator %+v", validator)
+	}
+
+	// The withdrawal statuses only show the validator as slashed through its record
+	if validator.Status.IsSlashed() || !validator.IsSlashed() {
+		t.Fatal("slashed validator not reported as slashed")
+	}
+
+	details := validator.Validator
+	if details.IsActiveAtEpoch(1) || !details.IsActiveAtEpoch(2) || !details.IsActiveAtEpoch(9) || details.IsActiveAtEpoch(10) {
+		t.Fatal("unexpected active epochs")
+	}
+	if details.HasExited(9) || !details.HasExited(10) {
+		t.Fatal("unexpected exit epochs")
+	}
+
+	details.ExitEpoch = FarFutureEpoch
+	if details.HasExited(phase0.Epoch(1 << 40)) {
+		t.Fatal("validator without exit epoch reported as exited")
+	}
+}
@@ -0,0 +1,98 @@
+package beaconclient
+
+import (
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	commonTypes "github.com/bsn-eng/pon-golang-types/common"
+)
+
+// WithdrawalsFromCapella converts consensus layer withdrawals to beacon API withdrawals
+func WithdrawalsFromCapella(withdrawals []*capella.Withdrawal) (Withdrawals, error) {
+	res := make(Withdrawals, len(withdrawals))
+	for i, w := range withdrawals {
+		if w == nil {
+			return nil, fmt.Errorf("withdrawal %d missing", i)
+		}
+		res[i] = Withdrawal{
+			Index:          uint64(w.Index),
+			ValidatorIndex: uint64(w.ValidatorIndex),
+			Address:        common.Address(w.Address).Hex(),
+			Amount:         uint64(w.Amount),
+		}
+	}
+	return res, nil
+}
+
+// WithdrawalsFromGeth converts execution layer withdrawals to beacon API withdrawals
+func WithdrawalsFromGeth(withdrawals types.Withdrawals) (Withdrawals, error) {
+	capellaWithdrawals, err := commonTypes.GethWithdrawalsToCapella(withdrawals)
+	if err != nil {
+		return nil, err
+	}
+	return WithdrawalsFromCapella(capellaWithdrawals)
+}
+
+// ToCapella converts the withdrawals to consensus layer withdrawals, failing on malformed addresses
+func (w Withdrawals) ToCapella() ([]*capella.Withdrawal, error) {
+	res := make([]*capella.Withdrawal, len(w))
+	for i, withdrawal := range w {
+		address, err := hexutil.Decode(withdrawal.Address)
+		if err != nil {
+			return nil, fmt.Errorf("withdrawal %d address: %w", i, err)
+		}
+		if len(address) != bellatrix.ExecutionAddressLength {
+			return nil, fmt.Errorf("withdrawal %d address has invalid length %d", i, len(address))
+		}
+		res[i] = &capella.Withdrawal{
+			Index:          capella.WithdrawalIndex(withdrawal.Index),
+			ValidatorIndex: phase0.ValidatorIndex(withdrawal.ValidatorIndex),
+			Address:        bellatrix.ExecutionAddress(address),
+			Amount:         phase0.Gwei(withdrawal.Amount),
+		}
+	}
+	return res, nil
+}
+
+// ToGeth converts the withdrawals to execution layer withdrawals, failing on malformed addresses
+func (w Withdrawals) ToGeth() (types.Withdrawals, error) {
+	capellaWithdrawals, err := w.ToCapella()
+	if err != nil {
+		return nil, err
+	}
+	return commonTypes.CapellaWithdrawalsToGeth(capellaWithdrawals)
+}
+
+// Root computes the ssz withdrawals root of an execution payload header from the withdrawals
+func (w Withdrawals) Root() (phase0.Root, error) {
+	capellaWithdrawals, err := w.ToCapella()
+	if err != nil {
+		return phase0.Root{}, err
+	}
+	return CapellaWithdrawalsRoot(capellaWithdrawals)
+}
+
+// CapellaWithdrawalsRoot computes the ssz withdrawals root of an execution payload header from consensus layer withdrawals
+func CapellaWithdrawalsRoot(withdrawals []*capella.Withdrawal) (phase0.Root, error) {
+	for i, w := range withdrawals {
+		if w == nil {
+			return phase0.Root{}, fmt.Errorf("withdrawal %d missing", i)
+		}
+	}
+	return commonTypes.ComputeWithdrawalsRoot(withdrawals)
+}
+
+// GethWithdrawalsRoot computes the ssz withdrawals root of an execution payload header from execution layer withdrawals
+func GethWithdrawalsRoot(withdrawals types.Withdrawals) (phase0.Root, error) {
+	capellaWithdrawals, err := commonTypes.GethWithdrawalsToCapella(withdrawals)
+	if err != nil {
+		return phase0.Root{}, err
+	}
+	return CapellaWithdrawalsRoot(capellaWithdrawals)
+}
@@ -0,0 +1,65 @@
+package beaconclient
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+
+	commonTypes "github.com/bsn-eng/pon-golang-types/common"
+)
+
+func testCapellaWithdrawals() []*capella.Withdrawal {
+	return []*capella.Withdrawal{
+		{Index: 1, ValidatorIndex: 2, Address: bellatrix.ExecutionAddress{0x03}, Amount: 4},
+		{Index: 5, ValidatorIndex: 6, Address: bellatrix.ExecutionAddress{0x07}, Amount: 8},
+	}
+}
+
+func TestWithdrawalsRoots(t *testing.T) {
+	capellaWithdrawals := testCapellaWithdrawals()
+	expected, err := commonTypes.ComputeWithdrawalsRoot(capellaWithdrawals)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := CapellaWithdrawalsRoot(capellaWithdrawals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root != expected {
+		t.Fatal("consensus layer withdrawals root differs")
+	}
+
+	withdrawals, err := WithdrawalsFromCapella(capellaWithdrawals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root, err = withdrawals.Root(); err != nil {
+		t.Fatal(err)
+	}
+	if root != expected {
+		t.Fatal("beacon API withdrawals root differs")
+	}
+
+	gethWithdrawals, err := withdrawals.ToGeth()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root, err = GethWithdrawalsRoot(gethWithdrawals); err != nil {
+		t.Fatal(err)
+	}
+	if root != expected {
+		t.Fatal("execution layer withdrawals root differs")
+	}
+}
+
+func TestWithdrawalsRootErrors(t *testing.T) {
+	if _, err := CapellaWithdrawalsRoot([]*capella.Withdrawal{nil}); err == nil {
+		t.Fatal("expected an error for a missing withdrawal")
+	}
+	withdrawals := Withdrawals{{Index: 1, Address: "0x01"}}
+	if _, err := withdrawals.Root(); err == nil {
+		t.Fatal("expected an error for a short address")
+	}
+}
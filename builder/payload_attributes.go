@@ -0,0 +1,90 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bsn-eng/pon-golang-types/beaconclient"
+	bundleTypes "github.com/bsn-eng/pon-golang-types/bundles"
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Relay Side Parameters Of The Payload Attributes
+type BuilderPayloadAttributesOpts struct {
+	BidAmount         *big.Int
+	PayoutPoolAddress gethCommon.Address
+	GasLimit          uint64
+	// SlotClock, if set, is used to check that the timestamp is the start of the proposal slot.
+	// The event carries no parent slot, so nothing checks that the proposal slot follows the parent block
+	SlotClock *beaconclient.SlotClock
+}
+
+// NewBuilderPayloadAttributes builds the payload attributes for the block builder from a payload_attributes event
+func NewBuilderPayloadAttributes(event *beaconclient.PayloadAttributesEventData, opts BuilderPayloadAttributesOpts) (*BuilderPayloadAttributes, error) {
+	if event == nil {
+		return nil, errors.New("no payload attributes event data set")
+	}
+	if event.ProposalSlot == 0 {
+		return nil, errors.New("proposal slot missing")
+	}
+
+	attributes := event.PayloadAttributes
+	if opts.SlotClock != nil {
+		slotStart := uint64(opts.SlotClock.SlotStartTime(event.ProposalSlot).Unix())
+		if attributes.Timestamp != slotStart {
+			return nil, fmt.Errorf("timestamp %d is not the start of slot %d at %d", attributes.Timestamp, event.ProposalSlot, slotStart)
+		}
+	}
+
+	var headHash gethCommon.Hash
+	if err := headHash.UnmarshalText([]byte(event.ParentBlockHash)); err != nil {
+		return nil, fmt.Errorf("invalid parent block hash: %w", err)
+	}
+	if headHash == (gethCommon.Hash{}) {
+		return nil, errors.New("parent block hash missing")
+	}
+
+	var random gethCommon.Hash
+	if err := random.UnmarshalText([]byte(attributes.PrevRandao)); err != nil {
+		return nil, fmt.Errorf("invalid prev randao: %w", err)
+	}
+
+	var feeRecipient gethCommon.Address
+	if err := feeRecipient.UnmarshalText([]byte(attributes.SuggestedFeeRecipient)); err != nil {
+		return nil, fmt.Errorf("invalid suggested fee recipient: %w", err)
+	}
+
+	withdrawals, err := attributes.Withdrawals.ToGeth()
+	if err != nil {
+		return nil, err
+	}
+
+	bidAmount := big.NewInt(0)
+	if opts.BidAmount != nil {
+		if opts.BidAmount.Sign() < 0 {
+			return nil, errors.New("bid amount is negative")
+		}
+		bidAmount.Set(opts.BidAmount)
+	}
+
+	return &BuilderPayloadAttributes{
+		Timestamp:             hexutil.Uint64(attributes.Timestamp),
+		Random:                random,
+		SuggestedFeeRecipient: feeRecipient,
+		Slot:                  event.ProposalSlot,
+		HeadHash:              headHash,
+		BidAmount:             bidAmount,
+		GasLimit:              opts.GasLimit,
+		Transactions:          [][]byte{},
+		PayoutPoolAddress:     opts.PayoutPoolAddress,
+		Withdrawals:           withdrawals,
+		BlockNumber:           event.ParentBlockNumber + 1,
+	}, nil
+}
+
+// SetBundles sets the bundles of the attributes to the candidates in the pool for the block built from the attributes
+func (b *BuilderPayloadAttributes) SetBundles(pool *bundleTypes.BundlePool) {
+	b.Bundles = pool.Candidates(b.BlockNumber, uint64(b.Timestamp))
+}
@@ -0,0 +1,133 @@
+package builder
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/bsn-eng/pon-golang-types/beaconclient"
+	bundleTypes "github.com/bsn-eng/pon-golang-types/bundles"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func testPayloadAttributesEvent() *beaconclient.PayloadAttributesEventData {
+	return &beaconclient.PayloadAttributesEventData{
+		ProposerIndex:     1,
+		ProposalSlot:      10,
+		ParentBlockNumber: 99,
+		ParentBlockRoot:   "0x0200000000000000000000000000000000000000000000000000000000000000",
+		ParentBlockHash:   "0x0300000000000000000000000000000000000000000000000000000000000000",
+		PayloadAttributes: beaconclient.PayloadAttributes{
+			Timestamp:             1606824143,
+			PrevRandao:            "0x0400000000000000000000000000000000000000000000000000000000000000",
+			SuggestedFeeRecipient: "0x0500000000000000000000000000000000000000",
+			Withdrawals: beaconclient.Withdrawals{
+				{Index: 1, ValidatorIndex: 2, Address: "0x0600000000000000000000000000000000000000", Amount: 3},
+			},
+		},
+	}
+}
+
+func TestNewBuilderPayloadAttributes(t *testing.T) {
+	attributes, err := NewBuilderPayloadAttributes(testPayloadAttributesEvent(), BuilderPayloadAttributesOpts{
+		BidAmount: big.NewInt(7),
+		GasLimit:  30000000,
+		SlotClock: beaconclient.NewSlotClock(1606824023, beaconclient.SlotClockOpts{}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attributes.BlockNumber != 100 {
+		t.Fatalf("expected block number 100, got %d", attributes.BlockNumber)
+	}
+	if attributes.Slot != 10 || attributes.HeadHash[0] != 0x03 || attributes.Random[0] != 0x04 {
+		t.Fatal("attributes not set from the event")
+	}
+	if len(attributes.Withdrawals) != 1 || attributes.Withdrawals[0].Amount != 3 {
+		t.Fatal("withdrawals not set from the event")
+	}
+	if attributes.BidAmount.Cmp(big.NewInt(7)) != 0 {
+		t.Fatalf("unexpected bid amount %s", attributes.BidAmount)
+	}
+}
+
+func TestNewBuilderPayloadAttributesErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(event *beaconclient.PayloadAttributesEventData, opts *BuilderPayloadAttributesOpts)
+	}{
+		{"missing proposal slot", func(event *beaconclient.PayloadAttributesEventData, _ *BuilderPayloadAttributesOpts) {
+			event.ProposalSlot = 0
+		}},
+		{"timestamp not at slot start", func(_ *beaconclient.PayloadAttributesEventData, opts *BuilderPayloadAttributesOpts) {
+			opts.SlotClock = beaconclient.NewSlotClock(1606824024, beaconclient.SlotClockOpts{})
+		}},
+		{"missing parent block hash", func(event *beaconclient.PayloadAttributesEventData, _ *BuilderPayloadAttributesOpts) {
+			event.ParentBlockHash = "0x0000000000000000000000000000000000000000000000000000000000000000"
+		}},
+		{"invalid fee recipient", func(event *beaconclient.PayloadAttributesEventData, _ *BuilderPayloadAttributesOpts) {
+			event.PayloadAttributes.SuggestedFeeRecipient = "0x05"
+		}},
+		{"negative bid amount", func(_ *beaconclient.PayloadAttributesEventData, opts *BuilderPayloadAttributesOpts) {
+			opts.BidAmount = big.NewInt(-1)
+		}},
+	}
+	for _, test := range tests {
+		event := testPayloadAttributesEvent()
+		opts := BuilderPayloadAttributesOpts{}
+		test.modify(event, &opts)
+		if _, err := NewBuilderPayloadAttributes(event, opts); err == nil {
+			t.Fatalf("%s: expected an error", test.name)
+		}
+	}
+	if _, err := NewBuilderPayloadAttributes(nil, BuilderPayloadAttributesOpts{}); err == nil {
+		t.Fatal("expected an error for a missing event")
+	}
+}
+
+func TestBuilderPayloadAttributesBlockNumberJSON(t *testing.T) {
+	attributes, err := NewBuilderPayloadAttributes(testPayloadAttributesEvent(), BuilderPayloadAttributesOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(attributes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"blockNumber":"100"`) {
+		t.Fatalf("block number not encoded: %s", data)
+	}
+
+	var res BuilderPayloadAttributes
+	if err := json.Unmarshal(data, &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.BlockNumber != 100 {
+		t.Fatalf("expected block number 100, got %d", res.BlockNumber)
+	}
+	if err := json.Unmarshal([]byte(`{"blockNumber":"0x01"}`), &res); err == nil {
+		t.Fatal("expected an error for an invalid block number")
+	}
+}
+
+func TestBuilderPayloadAttributesSetBundles(t *testing.T) {
+	attributes, err := NewBuilderPayloadAttributes(testPayloadAttributesEvent(), BuilderPayloadAttributesOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool := bundleTypes.NewBundlePool()
+	for i, blockNumber := range []uint64{99, 100, 101} {
+		tx := types.NewTx(&types.LegacyTx{Nonce: uint64(i), Gas: 21000, GasPrice: big.NewInt(1)})
+		bundle := &bundleTypes.BuilderBundle{Txs: []*types.Transaction{tx}, BlockNumber: blockNumber}
+		if _, err := pool.Add(bundle); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	attributes.SetBundles(pool)
+	if len(attributes.Bundles) != 1 || attributes.Bundles[0].BlockNumber != 100 {
+		t.Fatalf("expected the bundle for block 100, got %d bundles", len(attributes.Bundles))
+	}
+}
@@ -1,16 +1,282 @@
 package builder
 
 import (
+	"errors"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	commonTypes "github.com/bsn-eng/pon-golang-types/common"
+	rpbsTypes "github.com/bsn-eng/pon-golang-types/rpbs"
 	ssz "github.com/ferranbt/fastssz"
 )
 
+// bidPayloadFixedSize is the size of the fixed part of the BidPayload ssz encoding,
+// where the variable fields are replaced by 4 byte offsets
+const bidPayloadFixedSize = 276
+
+// MarshalSSZ ssz marshals the BidPayload object
+func (b *BidPayload) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(b)
+}
+
+// MarshalSSZTo ssz marshals the BidPayload object to a target array
+func (b *BidPayload) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(bidPayloadFixedSize)
+
+	if b.ExecutionPayloadHeader == nil {
+		return nil, errors.New("execution payload header missing")
+	}
+	// A missing RPBS signature is encoded as an empty one
+	rpbs := b.RPBS
+	if rpbs == nil {
+		rpbs = new(rpbsTypes.EncodedRPBSSignature)
+	}
+
+	// Field (0) 'Slot'
+	dst = ssz.MarshalUint64(dst, b.Slot)
+
+	// Field (1) 'ParentHash'
+	dst = append(dst, b.ParentHash[:]...)
+
+	// Field (2) 'BlockHash'
+	dst = append(dst, b.BlockHash[:]...)
+
+	// Field (3) 'BuilderPubkey'
+	dst = append(dst, b.BuilderPubkey[:]...)
+
+	// Field (4) 'ProposerPubkey'
+	dst = append(dst, b.ProposerPubkey[:]...)
+
+	// Field (5) 'ProposerFeeRecipient'
+	dst = append(dst, b.ProposerFeeRecipient[:]...)
+
+	// Field (6) 'GasLimit'
+	dst = ssz.MarshalUint64(dst, b.GasLimit)
+
+	// Field (7) 'GasUsed'
+	dst = ssz.MarshalUint64(dst, b.GasUsed)
+
+	// Field (8) 'Value'
+	value, err := commonTypes.BigIntToUint256SSZ(b.Value)
+	if err != nil {
+		return nil, err
+	}
+	dst = append(dst, value[:]...)
+
+	// Offset (9) 'ExecutionPayloadHeader'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += b.ExecutionPayloadHeader.SizeSSZ()
+
+	// Offset (10) 'Endpoint'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.Endpoint)
+
+	// Field (11) 'BuilderWalletAddress'
+	dst = append(dst, b.BuilderWalletAddress[:]...)
+
+	// Offset (12) 'PayoutPoolTransaction'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.PayoutPoolTransaction)
+
+	// Offset (13) 'RPBS'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += rpbs.SizeSSZ()
+
+	// Offset (14) 'RPBSPubkey'
+	dst = ssz.WriteOffset(dst, offset)
+
+	// Field (9) 'ExecutionPayloadHeader'
+	if dst, err = b.ExecutionPayloadHeader.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (10) 'Endpoint'
+	dst = append(dst, b.Endpoint...)
+
+	// Field (12) 'PayoutPoolTransaction'
+	dst = append(dst, b.PayoutPoolTransaction...)
+
+	// Field (13) 'RPBS'
+	if dst, err = rpbs.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (14) 'RPBSPubkey'
+	dst = append(dst, b.RPBSPubkey...)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the BidPayload object. The fork version of the execution payload header is
+// not part of the encoding, so each fork version is tried in turn and an electra header decodes as deneb.
+// Prefer UnmarshalSSZWithVersion when the fork version is known
+func (b *BidPayload) UnmarshalSSZ(buf []byte) error {
+	return b.unmarshalSSZ(spec.DataVersionUnknown, buf)
+}
+
+// UnmarshalSSZWithVersion ssz unmarshals the BidPayload object, decoding the execution payload header
+// as the given fork version
+func (b *BidPayload) UnmarshalSSZWithVersion(version spec.DataVersion, buf []byte) error {
+	if version == spec.DataVersionUnknown {
+		return errors.New("unknown execution payload header version")
+	}
+	return b.unmarshalSSZ(version, buf)
+}
+
+func (b *BidPayload) unmarshalSSZ(version spec.DataVersion, buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < bidPayloadFixedSize {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o9, o10, o12, o13, o14 uint64
+
+	// Field (0) 'Slot'
+	b.Slot = ssz.UnmarshallUint64(buf[0:8])
+
+	// Field (1) 'ParentHash'
+	copy(b.ParentHash[:], buf[8:40])
+
+	// Field (2) 'BlockHash'
+	copy(b.BlockHash[:], buf[40:72])
+
+	// Field (3) 'BuilderPubkey'
+	copy(b.BuilderPubkey[:], buf[72:120])
+
+	// Field (4) 'ProposerPubkey'
+	copy(b.ProposerPubkey[:], buf[120:168])
+
+	// Field (5) 'ProposerFeeRecipient'
+	copy(b.ProposerFeeRecipient[:], buf[168:188])
+
+	// Field (6) 'GasLimit'
+	b.GasLimit = ssz.UnmarshallUint64(buf[188:196])
+
+	// Field (7) 'GasUsed'
+	b.GasUsed = ssz.UnmarshallUint64(buf[196:204])
+
+	// Field (8) 'Value'
+	b.Value = commonTypes.Uint256SSZToBigInt(buf[204:236])
+
+	// Offset (9) 'ExecutionPayloadHeader'
+	if o9 = ssz.ReadOffset(buf[236:240]); o9 > size {
+		return ssz.ErrOffset
+	}
+
+	if o9 != bidPayloadFixedSize {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Offset (10) 'Endpoint'
+	if o10 = ssz.ReadOffset(buf[240:244]); o10 > size || o9 > o10 {
+		return ssz.ErrOffset
+	}
+
+	// Field (11) 'BuilderWalletAddress'
+	copy(b.BuilderWalletAddress[:], buf[244:264])
+
+	// Offset (12) 'PayoutPoolTransaction'
+	if o12 = ssz.ReadOffset(buf[264:268]); o12 > size || o10 > o12 {
+		return ssz.ErrOffset
+	}
+
+	// Offset (13) 'RPBS'
+	if o13 = ssz.ReadOffset(buf[268:272]); o13 > size || o12 > o13 {
+		return ssz.ErrOffset
+	}
+
+	// Offset (14) 'RPBSPubkey'
+	if o14 = ssz.ReadOffset(buf[272:276]); o14 > size || o13 > o14 {
+		return ssz.ErrOffset
+	}
+
+	// Field (9) 'ExecutionPayloadHeader'
+	{
+		buf = tail[o9:o10]
+		b.ExecutionPayloadHeader = new(commonTypes.VersionedExecutionPayloadHeader)
+		if version == spec.DataVersionUnknown {
+			err = b.ExecutionPayloadHeader.UnmarshalSSZ(buf)
+		} else {
+			err = b.ExecutionPayloadHeader.UnmarshalSSZWithVersion(version, buf)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// Field (10) 'Endpoint'
+	b.Endpoint = string(tail[o10:o12])
+
+	// Field (12) 'PayoutPoolTransaction'
+	b.PayoutPoolTransaction = append([]byte{}, tail[o12:o13]...)
+
+	// Field (13) 'RPBS'
+	{
+		buf = tail[o13:o14]
+		b.RPBS = new(rpbsTypes.EncodedRPBSSignature)
+		if err = b.RPBS.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	}
+
+	// Field (14) 'RPBSPubkey'
+	b.RPBSPubkey = string(tail[o14:])
+
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the BidPayload object
+func (b *BidPayload) SizeSSZ() (size int) {
+	size = bidPayloadFixedSize
+
+	// Field (9) 'ExecutionPayloadHeader'
+	if b.ExecutionPayloadHeader != nil {
+		size += b.ExecutionPayloadHeader.SizeSSZ()
+	}
+
+	// Field (10) 'Endpoint'
+	size += len(b.Endpoint)
+
+	// Field (12) 'PayoutPoolTransaction'
+	size += len(b.PayoutPoolTransaction)
+
+	// Field (13) 'RPBS'
+	if b.RPBS != nil {
+		size += b.RPBS.SizeSSZ()
+	} else {
+		size += new(rpbsTypes.EncodedRPBSSignature).SizeSSZ()
+	}
+
+	// Field (14) 'RPBSPubkey'
+	size += len(b.RPBSPubkey)
+
+	return
+}
+
 // HashTreeRoot ssz hashes the BidPayload object
 func (b *BidPayload) HashTreeRoot() ([32]byte, error) {
 	return ssz.HashWithDefaultHasher(b)
 }
 
+// LegacyHashTreeRoot ssz hashes the BidPayload object with the value encoded as its minimal little endian
+// bytes, which differs from HashTreeRoot for a zero value. Only use it to verify signatures made over the legacy root
+func (b *BidPayload) LegacyHashTreeRoot() ([32]byte, error) {
+	hh := ssz.DefaultHasherPool.Get()
+	defer ssz.DefaultHasherPool.Put(hh)
+	if err := b.hashTreeRootWith(hh, true); err != nil {
+		return [32]byte{}, err
+	}
+	return hh.HashRoot()
+}
+
 // HashTreeRootWith ssz hashes the BidPayload object with a hasher
 func (b *BidPayload) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	return b.hashTreeRootWith(hh, false)
+}
+
+func (b *BidPayload) hashTreeRootWith(hh ssz.HashWalker, legacyValue bool) (err error) {
 	indx := hh.Index()
 
 	// Field (0) 'Slot'
@@ -38,11 +304,15 @@ func (b *BidPayload) HashTreeRootWith(hh ssz.HashWalker) (err error) {
 	hh.PutUint64(b.GasUsed)
 
 	// Field (8) 'Value'
-	valueBytes := b.Value.Bytes() // Big endian
-	for i, j := 0, len(valueBytes)-1; i < j; i, j = i+1, j-1 {
-		valueBytes[i], valueBytes[j] = valueBytes[j], valueBytes[i]
-	} // Little endian
-	hh.PutBytes(valueBytes)
+	if legacyValue {
+		hh.PutBytes(commonTypes.BigIntToLegacySSZBytes(b.Value))
+	} else {
+		value, err := commonTypes.BigIntToUint256SSZ(b.Value)
+		if err != nil {
+			return err
+		}
+		hh.PutBytes(value[:])
+	}
 
 	// Field (9) 'ExecutionPayloadHeader'
 	headerRoot, err := b.ExecutionPayloadHeader.HashTreeRoot()
@@ -61,7 +331,11 @@ func (b *BidPayload) HashTreeRootWith(hh ssz.HashWalker) (err error) {
 	hh.PutBytes(b.PayoutPoolTransaction[:])
 
 	// Field (13) 'RPBS'
-	rpbsHash, err := b.RPBS.HashTreeRoot()
+	rpbs := b.RPBS
+	if rpbs == nil {
+		rpbs = new(rpbsTypes.EncodedRPBSSignature)
+	}
+	rpbsHash, err := rpbs.HashTreeRoot()
 	if err != nil {
 		return err
 	}
@@ -77,4 +351,4 @@ func (b *BidPayload) HashTreeRootWith(hh ssz.HashWalker) (err error) {
 // GetTree ssz hashes the BidPayload object
 func (b *BidPayload) GetTree() (*ssz.Node, error) {
 	return ssz.ProofTree(b)
-}
\ No newline at end of file
+}
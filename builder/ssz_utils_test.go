@@ -0,0 +1,139 @@
+package builder
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	bellatrix "github.com/attestantio/go-eth2-client/spec/bellatrix"
+	capella "github.com/attestantio/go-eth2-client/spec/capella"
+	commonTypes "github.com/bsn-eng/pon-golang-types/common"
+	rpbsTypes "github.com/bsn-eng/pon-golang-types/rpbs"
+	uint256 "github.com/holiman/uint256"
+)
+
+func testExecutionPayloadHeader(t *testing.T, version spec.DataVersion) *commonTypes.VersionedExecutionPayloadHeader {
+	t.Helper()
+	payload, err := commonTypes.ConstructExecutionPayload(version.String(), commonTypes.BaseExecutionPayload{
+		BlockNumber:   100,
+		GasLimit:      30000000,
+		Timestamp:     1700000000,
+		ExtraData:     []byte{},
+		BaseFeePerGas: uint256.NewInt(7),
+		Transactions:  []bellatrix.Transaction{{0x01}},
+		Withdrawals:   []*capella.Withdrawal{},
+		BlobGasUsed:   131072,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, err := payload.ToVersionedExecutionPayloadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &header
+}
+
+func testBidPayload(t *testing.T, version spec.DataVersion) *BidPayload {
+	t.Helper()
+	return &BidPayload{
+		Slot:                   10,
+		GasLimit:               30000000,
+		GasUsed:                21000,
+		Value:                  big.NewInt(1000),
+		ExecutionPayloadHeader: testExecutionPayloadHeader(t, version),
+		Endpoint:               "http://builder",
+		PayoutPoolTransaction:  []byte{0x02, 0x03},
+		RPBS:                   &rpbsTypes.EncodedRPBSSignature{Z1Hat: "z1", M1Hat: "m1"},
+		RPBSPubkey:             "pubkey",
+	}
+}
+
+func TestBidPayloadSSZWithVersion(t *testing.T) {
+	for _, version := range []spec.DataVersion{
+		spec.DataVersionBellatrix,
+		spec.DataVersionCapella,
+		spec.DataVersionDeneb,
+		spec.DataVersionElectra,
+	} {
+		bid := testBidPayload(t, version)
+		data, err := bid.MarshalSSZ()
+		if err != nil {
+			t.Fatalf("%s: %v", version, err)
+		}
+		if len(data) != bid.SizeSSZ() {
+			t.Fatalf("%s: expected %d bytes, got %d", version, bid.SizeSSZ(), len(data))
+		}
+
+		res := &BidPayload{}
+		if err := res.UnmarshalSSZWithVersion(version, data); err != nil {
+			t.Fatalf("%s: %v", version, err)
+		}
+		headerVersion, err := res.ExecutionPayloadHeader.VersionNumber()
+		if err != nil {
+			t.Fatalf("%s: %v", version, err)
+		}
+		if spec.DataVersion(headerVersion) != version {
+			t.Fatalf("%s: header decoded as %s", version, spec.DataVersion(headerVersion))
+		}
+
+		expected, err := bid.HashTreeRoot()
+		if err != nil {
+			t.Fatalf("%s: %v", version, err)
+		}
+		actual, err := res.HashTreeRoot()
+		if err != nil {
+			t.Fatalf("%s: %v", version, err)
+		}
+		if expected != actual {
+			t.Fatalf("%s: hash tree root changed through ssz", version)
+		}
+	}
+}
+
+func TestBidPayloadSSZUnknownVersion(t *testing.T) {
+	data, err := testBidPayload(t, spec.DataVersionCapella).MarshalSSZ()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := new(BidPayload).UnmarshalSSZWithVersion(spec.DataVersionUnknown, data); err == nil {
+		t.Fatal("expected an error for an unknown version")
+	}
+
+	// Without a version the header is decoded by trying each fork
+	res := &BidPayload{}
+	if err := res.UnmarshalSSZ(data); err != nil {
+		t.Fatal(err)
+	}
+	if res.ExecutionPayloadHeader.Capella == nil {
+		t.Fatal("header not decoded as capella")
+	}
+}
+
+func TestBidPayloadSSZNilRPBS(t *testing.T) {
+	bid := testBidPayload(t, spec.DataVersionDeneb)
+	bid.RPBS = nil
+
+	size := bid.SizeSSZ()
+	data, err := bid.MarshalSSZ()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bid.HashTreeRoot(); err != nil {
+		t.Fatal(err)
+	}
+	if bid.RPBS != nil {
+		t.Fatal("encoding set the RPBS signature of the bid")
+	}
+	if len(data) != size {
+		t.Fatalf("expected %d bytes, got %d", size, len(data))
+	}
+
+	res := &BidPayload{}
+	if err := res.UnmarshalSSZWithVersion(spec.DataVersionDeneb, data); err != nil {
+		t.Fatal(err)
+	}
+	if res.RPBS == nil || *res.RPBS != (rpbsTypes.EncodedRPBSSignature{}) {
+		t.Fatal("missing RPBS signature not decoded as an empty one")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"strconv"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -26,38 +27,105 @@ type BuilderPayloadAttributes struct {
 	Transactions          [][]byte                    `json:"transactions"`
 	NoMempoolTxs          bool                        `json:"noMempoolTxs,string"`
 	PayoutPoolAddress     gethCommon.Address          `json:"payoutPoolAddress"`
-	Withdrawals           types.Withdrawals           `json:"-"`
+	Withdrawals           types.Withdrawals           `json:"withdrawals"`
 	Bundles               []bundleTypes.BuilderBundle `json:"-"`
+	// BlockNumber is the number of the block built from the attributes, one past the parent block
+	BlockNumber uint64 `json:"blockNumber,string"`
 }
 
-// UnmarshalJSON implements the json.Unmarshaler interface purposefully for
-// receiving a payload from the API.
-func (b *BuilderPayloadAttributes) UnmarshalJSON(data []byte) error {
-	type BuilderPayloadAttributesJSON struct {
-		SuggestedFeeRecipient string   `json:"suggestedFeeRecipient"`
-		Slot                  string   `json:"slot"`
-		BidAmount             string   `json:"bidAmount"`
-		Transactions          []string `json:"transactions"`
-		NoMempoolTxs          string   `json:"noMempoolTxs"`
-		PayoutPoolAddress     string   `json:"payoutPoolAddress"`
+type builderPayloadAttributesJSON struct {
+	Timestamp             string             `json:"timestamp"`
+	Random                string             `json:"prevRandao"`
+	SuggestedFeeRecipient string             `json:"suggestedFeeRecipient"`
+	Slot                  string             `json:"slot"`
+	HeadHash              string             `json:"headHash"`
+	BidAmount             string             `json:"bidAmount"`
+	GasLimit              string             `json:"gasLimit"`
+	Transactions          []string           `json:"transactions"`
+	NoMempoolTxs          string             `json:"noMempoolTxs"`
+	PayoutPoolAddress     string             `json:"payoutPoolAddress"`
+	Withdrawals           *types.Withdrawals `json:"withdrawals,omitempty"`
+	BlockNumber           string             `json:"blockNumber"`
+}
+
+// MarshalJSON implements the json.Marshaler interface, encoding transactions as hex and
+// numbers as strings. Bundles are not encoded, as the builder attaches them from its own pool
+func (b BuilderPayloadAttributes) MarshalJSON() ([]byte, error) {
+	bidAmount := "0"
+	if b.BidAmount != nil {
+		bidAmount = b.BidAmount.String()
+	}
+
+	transactions := make([]string, len(b.Transactions))
+	for i, tx := range b.Transactions {
+		transactions[i] = hexutil.Encode(tx)
+	}
+
+	// Nil withdrawals are omitted so that they decode as nil rather than as an empty list
+	var withdrawals *types.Withdrawals
+	if b.Withdrawals != nil {
+		withdrawals = &b.Withdrawals
 	}
 
-	var aux BuilderPayloadAttributesJSON
+	return json.Marshal(&builderPayloadAttributesJSON{
+		Timestamp:             b.Timestamp.String(),
+		Random:                b.Random.Hex(),
+		SuggestedFeeRecipient: b.SuggestedFeeRecipient.Hex(),
+		Slot:                  strconv.FormatUint(b.Slot, 10),
+		HeadHash:              b.HeadHash.Hex(),
+		BidAmount:             bidAmount,
+		GasLimit:              strconv.FormatUint(b.GasLimit, 10),
+		Transactions:          transactions,
+		NoMempoolTxs:          strconv.FormatBool(b.NoMempoolTxs),
+		PayoutPoolAddress:     b.PayoutPoolAddress.Hex(),
+		Withdrawals:           withdrawals,
+		BlockNumber:           strconv.FormatUint(b.BlockNumber, 10),
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface purposefully for
+// receiving a payload from the API. Missing fields are set to their zero value
+// and malformed fields are reported by name
+func (b *BuilderPayloadAttributes) UnmarshalJSON(data []byte) error {
+	var aux builderPayloadAttributesJSON
 	if err := json.Unmarshal(data, &aux); err != nil {
 		return err
 	}
 
+	b.Timestamp = 0
+	if len(aux.Timestamp) > 0 {
+		if err := b.Timestamp.UnmarshalText([]byte(aux.Timestamp)); err != nil {
+			return fmt.Errorf("invalid timestamp %s: %w", aux.Timestamp, err)
+		}
+	}
+
+	b.Random = gethCommon.Hash{}
+	if len(aux.Random) > 0 {
+		if err := b.Random.UnmarshalText([]byte(aux.Random)); err != nil {
+			return fmt.Errorf("invalid prevRandao %s: %w", aux.Random, err)
+		}
+	}
+
 	b.SuggestedFeeRecipient = gethCommon.Address{}
 	if len(aux.SuggestedFeeRecipient) > 0 {
 		if err := b.SuggestedFeeRecipient.UnmarshalText([]byte(aux.SuggestedFeeRecipient)); err != nil {
-			return err
+			return fmt.Errorf("invalid suggestedFeeRecipient %s: %w", aux.SuggestedFeeRecipient, err)
 		}
 	}
 
 	b.Slot = 0
 	if len(aux.Slot) > 0 {
-		if _, err := fmt.Sscan(aux.Slot, &b.Slot); err != nil {
-			return err
+		slot, err := strconv.ParseUint(aux.Slot, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid slot %s: %w", aux.Slot, err)
+		}
+		b.Slot = slot
+	}
+
+	b.HeadHash = gethCommon.Hash{}
+	if len(aux.HeadHash) > 0 {
+		if err := b.HeadHash.UnmarshalText([]byte(aux.HeadHash)); err != nil {
+			return fmt.Errorf("invalid headHash %s: %w", aux.HeadHash, err)
 		}
 	}
 
@@ -66,25 +134,57 @@ func (b *BuilderPayloadAttributes) UnmarshalJSON(data []byte) error {
 		if _, ok := b.BidAmount.SetString(aux.BidAmount, 10); !ok {
 			return fmt.Errorf("failed to parse bid amount %s", aux.BidAmount)
 		}
+		if b.BidAmount.Sign() < 0 {
+			return fmt.Errorf("negative bid amount %s", aux.BidAmount)
+		}
+	}
+
+	b.GasLimit = 0
+	if len(aux.GasLimit) > 0 {
+		gasLimit, err := strconv.ParseUint(aux.GasLimit, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid gasLimit %s: %w", aux.GasLimit, err)
+		}
+		b.GasLimit = gasLimit
 	}
 
 	b.Transactions = make([][]byte, len(aux.Transactions))
 	for i, tx := range aux.Transactions {
-		b.Transactions[i] = []byte(tx)
+		txBytes, err := hexutil.Decode(tx)
+		if err != nil {
+			return fmt.Errorf("invalid transaction %d: %w", i, err)
+		}
+		b.Transactions[i] = txBytes
 	}
 
 	b.NoMempoolTxs = false
 	if len(aux.NoMempoolTxs) > 0 {
-		if _, err := fmt.Sscan(aux.NoMempoolTxs, &b.NoMempoolTxs); err != nil {
-			return err
+		noMempoolTxs, err := strconv.ParseBool(aux.NoMempoolTxs)
+		if err != nil {
+			return fmt.Errorf("invalid noMempoolTxs %s: %w", aux.NoMempoolTxs, err)
 		}
+		b.NoMempoolTxs = noMempoolTxs
 	}
 
 	b.PayoutPoolAddress = gethCommon.Address{}
 	if len(aux.PayoutPoolAddress) > 0 {
 		if err := b.PayoutPoolAddress.UnmarshalText([]byte(aux.PayoutPoolAddress)); err != nil {
-			return err
+			return fmt.Errorf("invalid payoutPoolAddress %s: %w", aux.PayoutPoolAddress, err)
+		}
+	}
+
+	b.Withdrawals = nil
+	if aux.Withdrawals != nil {
+		b.Withdrawals = *aux.Withdrawals
+	}
+
+	b.BlockNumber = 0
+	if len(aux.BlockNumber) > 0 {
+		blockNumber, err := strconv.ParseUint(aux.BlockNumber, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid blockNumber %s: %w", aux.BlockNumber, err)
 		}
+		b.BlockNumber = blockNumber
 	}
 
 	return nil
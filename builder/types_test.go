@@ -0,0 +1,107 @@
+package builder
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func testBuilderPayloadAttributes() BuilderPayloadAttributes {
+	return BuilderPayloadAttributes{
+		Timestamp:             1606824143,
+		Random:                gethCommon.Hash{0x01},
+		SuggestedFeeRecipient: gethCommon.Address{0x02},
+		Slot:                  10,
+		HeadHash:              gethCommon.Hash{0x03},
+		BidAmount:             big.NewInt(4),
+		GasLimit:              30000000,
+		Transactions:          [][]byte{{0x05}},
+		NoMempoolTxs:          true,
+		PayoutPoolAddress:     gethCommon.Address{0x06},
+		Withdrawals:           types.Withdrawals{{Index: 1, Validator: 2, Address: gethCommon.Address{0x07}, Amount: 3}},
+	}
+}
+
+func TestBuilderPayloadAttributesJSONValueAndPointer(t *testing.T) {
+	attributes := testBuilderPayloadAttributes()
+	fromValue, err := json.Marshal(attributes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromPointer, err := json.Marshal(&attributes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(fromValue) != string(fromPointer) {
+		t.Fatalf("value and pointer encode differently:\n%s\n%s", fromValue, fromPointer)
+	}
+	if !strings.Contains(string(fromValue), `"bidAmount":"4"`) {
+		t.Fatalf("value not encoded with the custom marshaler: %s", fromValue)
+	}
+
+	// Attributes nested by value use the custom marshaler as well
+	nested, err := json.Marshal(struct{ Attributes BuilderPayloadAttributes }{attributes})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(nested), string(fromValue)) {
+		t.Fatalf("nested value not encoded with the custom marshaler: %s", nested)
+	}
+
+	var res BuilderPayloadAttributes
+	if err := json.Unmarshal(fromValue, &res); err != nil {
+		t.Fatal(err)
+	}
+	roundTrip, err := json.Marshal(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(roundTrip) != string(fromValue) {
+		t.Fatalf("attributes changed through json:\n%s\n%s", fromValue, roundTrip)
+	}
+}
+
+func TestBuilderPayloadAttributesJSONWithdrawals(t *testing.T) {
+	tests := []struct {
+		name        string
+		withdrawals types.Withdrawals
+	}{
+		{"nil", nil},
+		{"empty", types.Withdrawals{}},
+	}
+	for _, test := range tests {
+		attributes := testBuilderPayloadAttributes()
+		attributes.Withdrawals = test.withdrawals
+		data, err := json.Marshal(attributes)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		var res BuilderPayloadAttributes
+		if err := json.Unmarshal(data, &res); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if (res.Withdrawals == nil) != (test.withdrawals == nil) || len(res.Withdrawals) != 0 {
+			t.Fatalf("%s: decoded withdrawals %v", test.name, res.Withdrawals)
+		}
+	}
+}
+
+func TestBuilderPayloadAttributesJSONNilBidAmount(t *testing.T) {
+	attributes := testBuilderPayloadAttributes()
+	attributes.BidAmount = nil
+	data, err := json.Marshal(attributes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var res BuilderPayloadAttributes
+	if err := json.Unmarshal(data, &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.BidAmount == nil || res.BidAmount.Sign() != 0 {
+		t.Fatalf("expected a zero bid amount, got %v", res.BidAmount)
+	}
+}
@@ -0,0 +1,220 @@
+package bundles
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+type ConflictKind string
+
+const (
+	// ConflictDuplicateTx is the same transaction in both bundles
+	ConflictDuplicateTx ConflictKind = "duplicate_tx"
+	// ConflictNonce is a different transaction from the same sender with the same nonce in both bundles
+	ConflictNonce ConflictKind = "nonce"
+	// ConflictRevertingTx is the same transaction allowed to revert by both bundles
+	ConflictRevertingTx ConflictKind = "reverting_tx"
+)
+
+// BundleConflict is an overlap between two bundles that prevents building both into a block
+type BundleConflict struct {
+	Kind ConflictKind
+	// First and Second are the positions of the bundles in the analysed list, First is before Second
+	First  int
+	Second int
+	// TxHash is the shared transaction of duplicate and reverting conflicts
+	TxHash common.Hash
+	// Sender and Nonce are the shared sender nonce of nonce conflicts
+	Sender common.Address
+	Nonce  uint64
+}
+
+func (c BundleConflict) String() string {
+	if c.Kind == ConflictNonce {
+		return fmt.Sprintf("bundles %d and %d: %s conflict on sender %s nonce %d", c.First, c.Second, c.Kind, c.Sender.Hex(), c.Nonce)
+	}
+	return fmt.Sprintf("bundles %d and %d: %s conflict on tx %s", c.First, c.Second, c.Kind, c.TxHash.Hex())
+}
+
+// BundleScorer ranks bundles for merging, higher scores are picked first
+type BundleScorer func(bundle *BuilderBundle) *big.Int
+
+type senderNonce struct {
+	sender common.Address
+	nonce  uint64
+}
+
+// bundleKeys are the transactions, sender nonces and reverting transactions of a bundle
+type bundleKeys struct {
+	txs       map[common.Hash]bool
+	nonces    map[senderNonce]common.Hash
+	reverting map[common.Hash]bool
+}
+
+// GasPriceScore scores a bundle by the sum of the gas price times the gas limit of its transactions
+func GasPriceScore(bundle *BuilderBundle) *big.Int {
+	score := big.NewInt(0)
+	for _, tx := range bundle.Txs {
+		score.Add(score, new(big.Int).Mul(tx.GasPrice(), new(big.Int).SetUint64(tx.Gas())))
+	}
+	return score
+}
+
+// EffectiveGasTipScore scores a bundle by the sum of the tip paid over the base fee times the gas limit of its
+// transactions, where transactions that cannot pay the base fee score nothing
+func EffectiveGasTipScore(baseFee *big.Int) BundleScorer {
+	return func(bundle *BuilderBundle) *big.Int {
+		score := big.NewInt(0)
+		for _, tx := range bundle.Txs {
+			tip, err := tx.EffectiveGasTip(baseFee)
+			if err != nil {
+				continue
+			}
+			score.Add(score, new(big.Int).Mul(tip, new(big.Int).SetUint64(tx.Gas())))
+		}
+		return score
+	}
+}
+
+// DetectConflicts returns every conflict between pairs of the bundles, recovering transaction senders with the signer
+func DetectConflicts(bundles []BuilderBundle, signer types.Signer) ([]BundleConflict, error) {
+	keys, err := collectBundleKeys(bundles, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := []BundleConflict{}
+	for i := range keys {
+		for j := i + 1; j < len(keys); j++ {
+			for _, conflict := range keys[i].conflicts(keys[j]) {
+				conflict.First, conflict.Second = i, j
+				conflicts = append(conflicts, conflict)
+			}
+		}
+	}
+	return conflicts, nil
+}
+
+// MergeBundles greedily picks bundles in order of descending score, skipping every bundle that conflicts
+// with one already picked. Bundles with equal scores keep their order. It returns the picked bundles in the
+// order they were picked and the skipped bundles in their original order
+func MergeBundles(bundles []BuilderBundle, signer types.Signer, score BundleScorer) ([]BuilderBundle, []BuilderBundle, error) {
+	keys, err := collectBundleKeys(bundles, signer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scores := make([]*big.Int, len(bundles))
+	order := make([]int, len(bundles))
+	for i := range bundles {
+		scores[i] = score(&bundles[i])
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return scores[order[a]].Cmp(scores[order[b]]) > 0
+	})
+
+	merged := &bundleKeys{
+		txs:       make(map[common.Hash]bool),
+		nonces:    make(map[senderNonce]common.Hash),
+		reverting: make(map[common.Hash]bool),
+	}
+	picked := make([]bool, len(bundles))
+	selected := []BuilderBundle{}
+	for _, i := range order {
+		if len(merged.conflicts(keys[i])) > 0 {
+			continue
+		}
+		merged.add(keys[i])
+		picked[i] = true
+		selected = append(selected, bundles[i])
+	}
+
+	skipped := []BuilderBundle{}
+	for i := range bundles {
+		if !picked[i] {
+			skipped = append(skipped, bundles[i])
+		}
+	}
+	return selected, skipped, nil
+}
+
+func collectBundleKeys(bundles []BuilderBundle, signer types.Signer) ([]*bundleKeys, error) {
+	res := make([]*bundleKeys, len(bundles))
+	for i, bundle := range bundles {
+		keys := &bundleKeys{
+			txs:       make(map[common.Hash]bool, len(bundle.Txs)),
+			nonces:    make(map[senderNonce]common.Hash, len(bundle.Txs)),
+			reverting: make(map[common.Hash]bool, len(bundle.RevertingTxHashes)),
+		}
+		for j, tx := range bundle.Txs {
+			if tx == nil {
+				return nil, fmt.Errorf("bundle %d transaction %d missing", i, j)
+			}
+			sender, err := types.Sender(signer, tx)
+			if err != nil {
+				return nil, fmt.Errorf("bundle %d transaction %d: %w", i, j, err)
+			}
+			keys.txs[tx.Hash()] = true
+			keys.nonces[senderNonce{sender: sender, nonce: tx.Nonce()}] = tx.Hash()
+		}
+		for _, txHash := range bundle.RevertingTxHashes {
+			if txHash != nil {
+				keys.reverting[*txHash] = true
+			}
+		}
+		res[i] = keys
+	}
+	return res, nil
+}
+
+// conflicts returns the conflicts between the keys, without the bundle positions
+func (k *bundleKeys) conflicts(other *bundleKeys) []BundleConflict {
+	conflicts := []BundleConflict{}
+	for txHash := range other.txs {
+		if k.txs[txHash] {
+			conflicts = append(conflicts, BundleConflict{Kind: ConflictDuplicateTx, TxHash: txHash})
+		}
+	}
+	for key, txHash := range other.nonces {
+		// The same transaction is already reported as a duplicate
+		if existing, ok := k.nonces[key]; ok && existing != txHash {
+			conflicts = append(conflicts, BundleConflict{Kind: ConflictNonce, Sender: key.sender, Nonce: key.nonce})
+		}
+	}
+	for txHash := range other.reverting {
+		if k.reverting[txHash] {
+			conflicts = append(conflicts, BundleConflict{Kind: ConflictRevertingTx, TxHash: txHash})
+		}
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].Kind != conflicts[j].Kind {
+			return conflicts[i].Kind < conflicts[j].Kind
+		}
+		if conflicts[i].Kind == ConflictNonce {
+			if conflicts[i].Sender != conflicts[j].Sender {
+				return conflicts[i].Sender.Hex() < conflicts[j].Sender.Hex()
+			}
+			return conflicts[i].Nonce < conflicts[j].Nonce
+		}
+		return conflicts[i].TxHash.Hex() < conflicts[j].TxHash.Hex()
+	})
+	return conflicts
+}
+
+func (k *bundleKeys) add(other *bundleKeys) {
+	for txHash := range other.txs {
+		k.txs[txHash] = true
+	}
+	for key, txHash := range other.nonces {
+		k.nonces[key] = txHash
+	}
+	for txHash := range other.reverting {
+		k.reverting[txHash] = true
+	}
+}
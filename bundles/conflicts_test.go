@@ -0,0 +1,161 @@
+package bundles
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestDetectConflicts(t *testing.T) {
+	alice, bob := testKey(t, 1), testKey(t, 2)
+	aliceTx := testTx(t, alice, 0, 1)
+	aliceReplacement := testTx(t, alice, 0, 2)
+	bobTx := testTx(t, bob, 0, 1)
+	bobTxHash := bobTx.Hash()
+
+	tests := []struct {
+		name     string
+		first    *BuilderBundle
+		second   *BuilderBundle
+		expected []ConflictKind
+	}{
+		{"independent", testBundle(t, 1, aliceTx), testBundle(t, 1, bobTx), nil},
+		{"duplicate tx", testBundle(t, 1, aliceTx), testBundle(t, 1, aliceTx, bobTx), []ConflictKind{ConflictDuplicateTx}},
+		{"nonce", testBundle(t, 1, aliceTx), testBundle(t, 1, aliceReplacement), []ConflictKind{ConflictNonce}},
+		{
+			"reverting tx",
+			&BuilderBundle{Txs: []*types.Transaction{bobTx}, RevertingTxHashes: []*common.Hash{&bobTxHash}},
+			&BuilderBundle{Txs: []*types.Transaction{bobTx}, RevertingTxHashes: []*common.Hash{&bobTxHash}},
+			[]ConflictKind{ConflictDuplicateTx, ConflictRevertingTx},
+		},
+	}
+	signer := types.LatestSignerForChainID(testChainID)
+	for _, test := range tests {
+		conflicts, err := DetectConflicts([]BuilderBundle{*test.first, *test.second}, signer)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if len(conflicts) != len(test.expected) {
+			t.Fatalf("%s: expected %d conflicts, got %v", test.name, len(test.expected), conflicts)
+		}
+		for i, conflict := range conflicts {
+			if conflict.Kind != test.expected[i] || conflict.First != 0 || conflict.Second != 1 {
+				t.Fatalf("%s: unexpected conflict %s", test.name, conflict)
+			}
+		}
+	}
+}
+
+func TestDetectConflictsNonceSender(t *testing.T) {
+	alice := testKey(t, 1)
+	bundles := []BuilderBundle{
+		*testBundle(t, 1, testTx(t, alice, 3, 1)),
+		*testBundle(t, 1, testTx(t, alice, 3, 2)),
+	}
+	conflicts, err := DetectConflicts(bundles, types.LatestSignerForChainID(testChainID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(testChainID), bundles[0].Txs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 1 || conflicts[0].Sender != sender || conflicts[0].Nonce != 3 {
+		t.Fatalf("unexpected conflicts %v", conflicts)
+	}
+}
+
+func TestMergeBundles(t *testing.T) {
+	alice, bob, carol := testKey(t, 1), testKey(t, 2), testKey(t, 3)
+	signer := types.LatestSignerForChainID(testChainID)
+
+	tests := []struct {
+		name     string
+		bundles  []BuilderBundle
+		selected []int
+		skipped  []int
+	}{
+		{
+			"higher score wins a conflict",
+			[]BuilderBundle{*testBundle(t, 1, testTx(t, alice, 0, 1)), *testBundle(t, 1, testTx(t, alice, 0, 2))},
+			[]int{1},
+			[]int{0},
+		},
+		{
+			"equal scores keep their order",
+			[]BuilderBundle{
+				*testBundle(t, 1, testTx(t, alice, 0, 1)),
+				*testBundle(t, 1, testTx(t, bob, 0, 1)),
+				*testBundle(t, 1, testTx(t, carol, 0, 1)),
+			},
+			[]int{0, 1, 2},
+			nil,
+		},
+		{
+			"equal scores conflict, the first wins",
+			[]BuilderBundle{
+				*testBundle(t, 1, testTx(t, bob, 0, 1)),
+				*testBundle(t, 1, testTx(t, alice, 0, 1)),
+				*testBundle(t, 1, testTx(t, alice, 0, 1)),
+			},
+			[]int{0, 1},
+			[]int{2},
+		},
+	}
+	for _, test := range tests {
+		selected, skipped, err := MergeBundles(test.bundles, signer, GasPriceScore)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if len(selected) != len(test.selected) || len(skipped) != len(test.skipped) {
+			t.Fatalf("%s: selected %d and skipped %d bundles", test.name, len(selected), len(skipped))
+		}
+		for i, index := range test.selected {
+			if selected[i].BundleHash != test.bundles[index].BundleHash {
+				t.Fatalf("%s: selected bundle %d is not bundle %d", test.name, i, index)
+			}
+		}
+		for i, index := range test.skipped {
+			if skipped[i].BundleHash != test.bundles[index].BundleHash {
+				t.Fatalf("%s: skipped bundle %d is not bundle %d", test.name, i, index)
+			}
+		}
+	}
+}
+
+func TestMergeBundlesEffectiveGasTip(t *testing.T) {
+	alice, bob := testKey(t, 1), testKey(t, 2)
+	bundles := []BuilderBundle{
+		*testBundle(t, 1, testTx(t, alice, 0, 5)),
+		*testBundle(t, 1, testTx(t, bob, 0, 20)),
+	}
+	selected, _, err := MergeBundles(bundles, types.LatestSignerForChainID(testChainID), EffectiveGasTipScore(big.NewInt(10)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(selected) != 2 || selected[0].BundleHash != bundles[1].BundleHash {
+		t.Fatal("bundle paying over the base fee not picked first")
+	}
+}
+
+func TestConflictsMissingTx(t *testing.T) {
+	bundles := []BuilderBundle{{Txs: []*types.Transaction{nil}}}
+	signer := types.LatestSignerForChainID(testChainID)
+	if _, err := DetectConflicts(bundles, signer); err == nil {
+		t.Fatal("expected an error for a missing transaction")
+	}
+	if _, _, err := MergeBundles(bundles, signer, GasPriceScore); err == nil {
+		t.Fatal("expected an error for a missing transaction")
+	}
+}
+
+func TestConflictsWrongChain(t *testing.T) {
+	bundles := []BuilderBundle{*testBundle(t, 1, testTx(t, testKey(t, 1), 0, 1))}
+	_, err := DetectConflicts(bundles, types.LatestSignerForChainID(big.NewInt(2)))
+	if !errors.Is(err, types.ErrInvalidChainId) {
+		t.Fatalf("expected an invalid chain id error, got %v", err)
+	}
+}
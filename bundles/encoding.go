@@ -0,0 +1,162 @@
+package bundles
+
+import (
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// hexListEncodingVersion is the version of the database encoding written by HexList
+const hexListEncodingVersion = 1
+
+// HexList is a list of hex encoded byte strings, such as transactions or transaction hashes, stored in one
+// database column as a versioned JSON document with a checksum. Columns written in the legacy comma separated
+// format are still read
+type HexList []string
+
+type hexListDocument struct {
+	Version  int      `json:"version"`
+	Items    []string `json:"items"`
+	Checksum string   `json:"checksum"`
+}
+
+// Value implements the driver.Valuer interface
+func (l HexList) Value() (driver.Value, error) {
+	if err := l.validate(); err != nil {
+		return nil, err
+	}
+	items := []string(l)
+	if items == nil {
+		items = []string{}
+	}
+	checksum, err := hexListChecksum(items)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(&hexListDocument{
+		Version:  hexListEncodingVersion,
+		Items:    items,
+		Checksum: checksum,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements the sql.Scanner interface, where NULL scans as an empty list
+func (l *HexList) Scan(src interface{}) error {
+	var data string
+	switch v := src.(type) {
+	case nil:
+		*l = HexList{}
+		return nil
+	case string:
+		data = v
+	case []byte:
+		data = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into hex list", src)
+	}
+
+	list, err := DecodeHexList(data)
+	if err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, accepting a JSON array or a legacy comma separated string
+func (l *HexList) UnmarshalJSON(data []byte) error {
+	var items []string
+	if err := json.Unmarshal(data, &items); err == nil {
+		list := HexList(items)
+		if err := list.validate(); err != nil {
+			return err
+		}
+		*l = list
+		return nil
+	}
+
+	var legacy string
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return fmt.Errorf("error decoding hex list: %v", err)
+	}
+	list, err := ParseLegacyHexList(legacy)
+	if err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+// DecodeHexList decodes a hex list column, in the versioned format or in the legacy comma separated format
+func DecodeHexList(data string) (HexList, error) {
+	if !strings.HasPrefix(strings.TrimSpace(data), "{") {
+		return ParseLegacyHexList(data)
+	}
+
+	var document hexListDocument
+	if err := json.Unmarshal([]byte(data), &document); err != nil {
+		return nil, fmt.Errorf("error decoding hex list: %v", err)
+	}
+	if document.Version != hexListEncodingVersion {
+		return nil, fmt.Errorf("unsupported hex list version %d", document.Version)
+	}
+	if document.Items == nil {
+		document.Items = []string{}
+	}
+	checksum, err := hexListChecksum(document.Items)
+	if err != nil {
+		return nil, err
+	}
+	if checksum != document.Checksum {
+		return nil, fmt.Errorf("hex list checksum mismatch: expected %s, got %s", checksum, document.Checksum)
+	}
+
+	list := HexList(document.Items)
+	if err := list.validate(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// ParseLegacyHexList parses the comma separated format, skipping empty items
+func ParseLegacyHexList(data string) (HexList, error) {
+	list := HexList{}
+	for _, item := range strings.Split(data, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		list = append(list, item)
+	}
+	if err := list.validate(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (l HexList) validate() error {
+	for i, item := range l {
+		if _, err := hexutil.Decode(item); err != nil {
+			return fmt.Errorf("hex list item %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// hexListChecksum is the sha256 hash of the JSON encoded items
+func hexListChecksum(items []string) (string, error) {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return "", err
+	}
+	checksum := sha256.Sum256(data)
+	return hexutil.Encode(checksum[:]), nil
+}
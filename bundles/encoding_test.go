@@ -0,0 +1,115 @@
+package bundles
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestHexListValueAndScan(t *testing.T) {
+	tests := []struct {
+		name string
+		list HexList
+	}{
+		{"items", HexList{"0x01", "0x0203"}},
+		{"empty", HexList{}},
+		{"nil", nil},
+	}
+	for _, test := range tests {
+		value, err := test.list.Value()
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		data, ok := value.(string)
+		if !ok || !strings.Contains(data, `"version":1`) {
+			t.Fatalf("%s: unexpected column %v", test.name, value)
+		}
+
+		for _, src := range []interface{}{data, []byte(data)} {
+			var res HexList
+			if err := res.Scan(src); err != nil {
+				t.Fatalf("%s: %v", test.name, err)
+			}
+			if res == nil || len(res) != len(test.list) {
+				t.Fatalf("%s: scanned %v", test.name, res)
+			}
+			for i := range res {
+				if res[i] != test.list[i] {
+					t.Fatalf("%s: scanned %v", test.name, res)
+				}
+			}
+		}
+	}
+
+	var res HexList
+	if err := res.Scan(nil); err != nil || res == nil || len(res) != 0 {
+		t.Fatalf("expected an empty list for NULL, got %v and %v", res, err)
+	}
+	if err := res.Scan(1); err == nil {
+		t.Fatal("expected an error scanning an int")
+	}
+	if _, err := (HexList{"zz"}).Value(); err == nil {
+		t.Fatal("expected an error for a malformed item")
+	}
+}
+
+func TestDecodeHexList(t *testing.T) {
+	value, err := HexList{"0x01"}.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := value.(string)
+
+	tests := []struct {
+		name     string
+		data     string
+		expected HexList
+	}{
+		{"versioned", data, HexList{"0x01"}},
+		{"legacy", "0x01, 0x02,,", HexList{"0x01", "0x02"}},
+		{"legacy empty", "", HexList{}},
+	}
+	for _, test := range tests {
+		list, err := DecodeHexList(test.data)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if strings.Join(list, ",") != strings.Join(test.expected, ",") || list == nil {
+			t.Fatalf("%s: decoded %v", test.name, list)
+		}
+	}
+
+	errorTests := []struct {
+		name string
+		data string
+	}{
+		{"checksum", strings.Replace(data, `"0x01"`, `"0x02"`, 1)},
+		{"version", strings.Replace(data, `"version":1`, `"version":2`, 1)},
+		{"malformed document", "{"},
+		{"malformed legacy item", "0x01,zz"},
+	}
+	for _, test := range errorTests {
+		if _, err := DecodeHexList(test.data); err == nil {
+			t.Fatalf("%s: expected an error", test.name)
+		}
+	}
+}
+
+func TestHexListUnmarshalJSON(t *testing.T) {
+	var entry struct {
+		Txs HexList `json:"txs"`
+	}
+	for _, data := range []string{`{"txs":["0x01","0x02"]}`, `{"txs":"0x01,0x02"}`} {
+		if err := json.Unmarshal([]byte(data), &entry); err != nil {
+			t.Fatal(err)
+		}
+		if strings.Join(entry.Txs, ",") != "0x01,0x02" {
+			t.Fatalf("decoded %v from %s", entry.Txs, data)
+		}
+	}
+	for _, data := range []string{`{"txs":["zz"]}`, `{"txs":1}`} {
+		if err := json.Unmarshal([]byte(data), &entry); err == nil {
+			t.Fatalf("expected an error decoding %s", data)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package bundles
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// BundleState is the stage of a bundle in its lifecycle, received → pending → adding → added → included,
+// where a bundle may fail or expire before it is included
+type BundleState string
+
+const (
+	BundleStateReceived BundleState = "received"
+	BundleStatePending  BundleState = "pending"
+	BundleStateAdding   BundleState = "adding"
+	BundleStateAdded    BundleState = "added"
+	BundleStateIncluded BundleState = "included"
+	BundleStateFailed   BundleState = "failed"
+	BundleStateExpired  BundleState = "expired"
+)
+
+var ErrInvalidTransition = errors.New("invalid bundle state transition")
+
+var bundleTransitions = map[BundleState][]BundleState{
+	BundleStateReceived: {BundleStatePending, BundleStateFailed, BundleStateExpired},
+	BundleStatePending:  {BundleStateAdding, BundleStateFailed, BundleStateExpired},
+	// Adding returns to pending when an attempt to add the bundle fails and may be retried
+	BundleStateAdding: {BundleStateAdded, BundleStatePending, BundleStateFailed, BundleStateExpired},
+	BundleStateAdded:  {BundleStateIncluded, BundleStateFailed, BundleStateExpired},
+}
+
+// BundleTransition records a bundle entering a state
+type BundleTransition struct {
+	From BundleState `json:"from"`
+	To   BundleState `json:"to"`
+	At   time.Time   `json:"at"`
+}
+
+// Bundle Retry Parameters
+type BundleRetryPolicy struct {
+	// MaxRetries is the number of failed attempts to add a bundle after which it fails
+	MaxRetries uint64
+}
+
+var DefaultBundleRetryPolicy = BundleRetryPolicy{MaxRetries: 3}
+
+func (s BundleState) IsKnown() bool {
+	_, ok := bundleTransitions[s]
+	return ok || s.IsTerminal()
+}
+
+// IsTerminal reports whether the bundle can no longer change state
+func (s BundleState) IsTerminal() bool {
+	return s == BundleStateIncluded || s == BundleStateFailed || s == BundleStateExpired
+}
+
+// CanTransition reports whether a bundle may move from one state to the other
+func CanTransition(from, to BundleState) bool {
+	for _, next := range bundleTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// CurrentState returns the state of the bundle, a bundle without a state has just been received
+func (b *BuilderBundle) CurrentState() BundleState {
+	if b.State == "" {
+		return BundleStateReceived
+	}
+	return b.State
+}
+
+// Transition moves the bundle to the state at the time, failing if the lifecycle does not allow it
+func (b *BuilderBundle) Transition(to BundleState, at time.Time) error {
+	from := b.CurrentState()
+	if !CanTransition(from, to) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, from, to)
+	}
+	b.State = to
+	b.Transitions = append(b.Transitions, BundleTransition{
+		From: from,
+		To:   to,
+		At:   at,
+	})
+	return nil
+}
+
+// Fail moves the bundle to the failed state and records the reason
+func (b *BuilderBundle) Fail(reason string, at time.Time) error {
+	if err := b.Transition(BundleStateFailed, at); err != nil {
+		return err
+	}
+	b.ErrorMessage = reason
+	return nil
+}
+
+// RetryAdd records a failed attempt to add the bundle, returning it to pending to be retried,
+// or failing it once the policy allows no more retries
+func (b *BuilderBundle) RetryAdd(reason string, policy BundleRetryPolicy, at time.Time) error {
+	if from := b.CurrentState(); from != BundleStateAdding {
+		return fmt.Errorf("%w: retry from %s", ErrInvalidTransition, from)
+	}
+	b.FailedRetryCount++
+	if b.FailedRetryCount > policy.MaxRetries {
+		return b.Fail(reason, at)
+	}
+	if err := b.Transition(BundleStatePending, at); err != nil {
+		return err
+	}
+	b.ErrorMessage = reason
+	return nil
+}
+
+// StateTime returns when the bundle last entered the state
+func (b *BuilderBundle) StateTime(state BundleState) (time.Time, bool) {
+	for i := len(b.Transitions) - 1; i >= 0; i-- {
+		if b.Transitions[i].To == state {
+			return b.Transitions[i].At, true
+		}
+	}
+	if state == BundleStateReceived && !b.BundleDateTime.IsZero() {
+		return b.BundleDateTime, true
+	}
+	return time.Time{}, false
+}
+
+// IsAdded reports whether the bundle has been added to a block, including bundles since included
+func (b *BuilderBundle) IsAdded() bool {
+	state := b.CurrentState()
+	return state == BundleStateAdded || state == BundleStateIncluded
+}
+
+func (b *BuilderBundle) IsFailed() bool {
+	return b.CurrentState() == BundleStateFailed
+}
+
+// encodeTransitions encodes the transitions for the bundle entry, where no transitions encode as an empty string
+func encodeTransitions(transitions []BundleTransition) (string, error) {
+	if len(transitions) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(transitions)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func decodeTransitions(data string) ([]BundleTransition, error) {
+	if data == "" {
+		return nil, nil
+	}
+	var transitions []BundleTransition
+	if err := json.Unmarshal([]byte(data), &transitions); err != nil {
+		return nil, err
+	}
+	return transitions, nil
+}
+
+// entryState returns the state of a bundle entry, deriving it from the added and error flags
+// for entries stored before bundle states were persisted
+func entryState(b *BuilderBundleEntry) (BundleState, error) {
+	if b.State != "" {
+		state := BundleState(b.State)
+		if !state.IsKnown() {
+			return "", fmt.Errorf("unknown bundle state %s", b.State)
+		}
+		return state, nil
+	}
+	switch {
+	case b.Error:
+		return BundleStateFailed, nil
+	case b.Added:
+		return BundleStateAdded, nil
+	default:
+		return BundleStatePending, nil
+	}
+}
@@ -0,0 +1,155 @@
+package bundles
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBundleTransitions(t *testing.T) {
+	tests := []struct {
+		from, to BundleState
+		allowed  bool
+	}{
+		{BundleStateReceived, BundleStatePending, true},
+		{BundleStatePending, BundleStateAdding, true},
+		{BundleStateAdding, BundleStatePending, true},
+		{BundleStateAdding, BundleStateAdded, true},
+		{BundleStateAdded, BundleStateIncluded, true},
+		{BundleStateAdded, BundleStateExpired, true},
+		{BundleStateReceived, BundleStateAdded, false},
+		{BundleStatePending, BundleStateIncluded, false},
+		{BundleStateIncluded, BundleStateFailed, false},
+		{BundleStateFailed, BundleStatePending, false},
+		{BundleStateExpired, BundleStateExpired, false},
+	}
+	for _, test := range tests {
+		if CanTransition(test.from, test.to) != test.allowed {
+			t.Fatalf("%s to %s: expected allowed %v", test.from, test.to, test.allowed)
+		}
+	}
+
+	for _, state := range []BundleState{BundleStateIncluded, BundleStateFailed, BundleStateExpired} {
+		if !state.IsTerminal() || !state.IsKnown() {
+			t.Fatalf("%s should be a known terminal state", state)
+		}
+	}
+	if BundleState("unknown").IsKnown() {
+		t.Fatal("unknown state reported as known")
+	}
+}
+
+func TestBundleLifecycle(t *testing.T) {
+	bundle := &BuilderBundle{BundleDateTime: time.Unix(1, 0)}
+	if bundle.CurrentState() != BundleStateReceived {
+		t.Fatalf("new bundle is %s", bundle.CurrentState())
+	}
+	if at, ok := bundle.StateTime(BundleStateReceived); !ok || !at.Equal(time.Unix(1, 0)) {
+		t.Fatal("received time not taken from the bundle time")
+	}
+
+	for i, state := range []BundleState{BundleStatePending, BundleStateAdding, BundleStateAdded} {
+		if err := bundle.Transition(state, time.Unix(int64(i+2), 0)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !bundle.IsAdded() || bundle.IsFailed() || len(bundle.Transitions) != 3 {
+		t.Fatal("bundle not added")
+	}
+	if bundle.Transitions[2].From != BundleStateAdding || bundle.Transitions[2].To != BundleStateAdded {
+		t.Fatalf("unexpected transition %+v", bundle.Transitions[2])
+	}
+	if at, ok := bundle.StateTime(BundleStateAdding); !ok || !at.Equal(time.Unix(3, 0)) {
+		t.Fatal("unexpected adding time")
+	}
+
+	err := bundle.Transition(BundleStatePending, time.Unix(5, 0))
+	if !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("expected an invalid transition, got %v", err)
+	}
+	if bundle.CurrentState() != BundleStateAdded || len(bundle.Transitions) != 3 {
+		t.Fatal("invalid transition changed the bundle")
+	}
+
+	if err := bundle.Fail("reverted", time.Unix(6, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if !bundle.IsFailed() || bundle.ErrorMessage != "reverted" {
+		t.Fatal("bundle not failed")
+	}
+}
+
+func TestBundleRetryAdd(t *testing.T) {
+	policy := BundleRetryPolicy{MaxRetries: 1}
+	bundle := &BuilderBundle{State: BundleStateAdding}
+
+	if err := bundle.RetryAdd("first", policy, time.Unix(1, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if bundle.CurrentState() != BundleStatePending || bundle.FailedRetryCount != 1 || bundle.ErrorMessage != "first" {
+		t.Fatalf("bundle not returned to pending, state %s", bundle.CurrentState())
+	}
+
+	if err := bundle.RetryAdd("not adding", policy, time.Unix(2, 0)); !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("expected an invalid transition, got %v", err)
+	}
+
+	if err := bundle.Transition(BundleStateAdding, time.Unix(3, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if err := bundle.RetryAdd("second", policy, time.Unix(4, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if !bundle.IsFailed() || bundle.FailedRetryCount != 2 || bundle.ErrorMessage != "second" {
+		t.Fatalf("bundle not failed after the last retry, state %s", bundle.CurrentState())
+	}
+}
+
+func TestBundleEntryState(t *testing.T) {
+	key := testKey(t, 1)
+	bundle := testBundle(t, 100, testTx(t, key, 0, 1))
+	bundle.BundleDateTime = time.Unix(1, 0).UTC()
+	if err := bundle.Transition(BundleStatePending, time.Unix(2, 0).UTC()); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, err := BuilderBundleToEntry(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.State != string(BundleStatePending) || entry.Added || entry.Error {
+		t.Fatalf("unexpected entry state %s", entry.State)
+	}
+	res, err := BuilderBundleEntryToBundle(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.CurrentState() != BundleStatePending || len(res.Transitions) != 1 || !res.Transitions[0].At.Equal(time.Unix(2, 0)) {
+		t.Fatal("state changed through the entry")
+	}
+
+	// Entries stored before states were persisted derive their state from their flags
+	tests := []struct {
+		added, failed bool
+		expected      BundleState
+	}{
+		{false, false, BundleStatePending},
+		{true, false, BundleStateAdded},
+		{true, true, BundleStateFailed},
+	}
+	for _, test := range tests {
+		entry.State, entry.Transitions, entry.Added, entry.Error = "", "", test.added, test.failed
+		res, err := BuilderBundleEntryToBundle(entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.CurrentState() != test.expected {
+			t.Fatalf("expected %s, got %s", test.expected, res.CurrentState())
+		}
+	}
+
+	entry.State = "unknown"
+	if _, err := BuilderBundleEntryToBundle(entry); err == nil {
+		t.Fatal("expected an error for an unknown state")
+	}
+}
@@ -0,0 +1,203 @@
+package bundles
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var ErrBundleNotFound = errors.New("bundle not found")
+
+// BundlePool holds the bundles waiting to be built into a block, indexed by bundle hash and target block.
+// It is safe for concurrent use, and hands out copies so that callers never share a bundle with the pool
+type BundlePool struct {
+	mu      sync.RWMutex
+	byHash  map[string]*BuilderBundle
+	byBlock map[uint64]map[string]*BuilderBundle
+}
+
+func NewBundlePool() *BundlePool {
+	return &BundlePool{
+		byHash:  make(map[string]*BuilderBundle),
+		byBlock: make(map[uint64]map[string]*BuilderBundle),
+	}
+}
+
+// Add adds a copy of the bundle to the pool, computing its bundle hash if it is not set. It reports
+// false if a bundle with the same hash is already in the pool
+func (p *BundlePool) Add(bundle *BuilderBundle) (bool, error) {
+	if bundle == nil {
+		return false, errors.New("no bundle set")
+	}
+	if bundle.CurrentState().IsTerminal() {
+		return false, fmt.Errorf("bundle is %s", bundle.CurrentState())
+	}
+
+	entry := copyBundle(bundle)
+	if entry.BundleHash == "" {
+		bundleHash, err := entry.ComputeBundleHash()
+		if err != nil {
+			return false, err
+		}
+		entry.BundleHash = bundleHash.Hex()
+	} else if err := entry.VerifyBundleHash(); err != nil {
+		return false, err
+	}
+	key := strings.ToLower(entry.BundleHash)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.byHash[key]; ok {
+		return false, nil
+	}
+	p.byHash[key] = &entry
+	if p.byBlock[entry.BlockNumber] == nil {
+		p.byBlock[entry.BlockNumber] = make(map[string]*BuilderBundle)
+	}
+	p.byBlock[entry.BlockNumber][key] = &entry
+	return true, nil
+}
+
+// Get returns a copy of the bundle with the hash
+func (p *BundlePool) Get(bundleHash string) (*BuilderBundle, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	bundle, ok := p.byHash[strings.ToLower(bundleHash)]
+	if !ok {
+		return nil, false
+	}
+	res := copyBundle(bundle)
+	return &res, true
+}
+
+func (p *BundlePool) Len() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.byHash)
+}
+
+// Remove removes the bundle with the hash, reporting whether it was in the pool
+func (p *BundlePool) Remove(bundleHash string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.remove(strings.ToLower(bundleHash))
+}
+
+// Transition moves the bundle with the hash to the state. Bundles reaching a terminal state leave the pool,
+// and a copy of the bundle in its new state is returned so that it can be persisted
+func (p *BundlePool) Transition(bundleHash string, to BundleState, at time.Time) (*BuilderBundle, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := strings.ToLower(bundleHash)
+	bundle, ok := p.byHash[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrBundleNotFound, bundleHash)
+	}
+
+	updated := copyBundle(bundle)
+	if err := updated.Transition(to, at); err != nil {
+		return nil, err
+	}
+
+	if to.IsTerminal() {
+		p.remove(key)
+	} else {
+		p.byHash[key] = &updated
+		p.byBlock[updated.BlockNumber][key] = &updated
+	}
+	res := copyBundle(&updated)
+	return &res, nil
+}
+
+// Candidates returns copies of the bundles targeting the block whose timestamp window contains the timestamp,
+// in the order they were received
+func (p *BundlePool) Candidates(blockNumber, timestamp uint64) []BuilderBundle {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	candidates := []BuilderBundle{}
+	for _, bundle := range p.byBlock[blockNumber] {
+		if bundle.MinTimestamp != 0 && timestamp < bundle.MinTimestamp {
+			continue
+		}
+		if bundle.MaxTimestamp != 0 && timestamp > bundle.MaxTimestamp {
+			continue
+		}
+		candidates = append(candidates, copyBundle(bundle))
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if !candidates[i].BundleDateTime.Equal(candidates[j].BundleDateTime) {
+			return candidates[i].BundleDateTime.Before(candidates[j].BundleDateTime)
+		}
+		return candidates[i].BundleHash < candidates[j].BundleHash
+	})
+	return candidates
+}
+
+// Evict removes the bundles targeting blocks up to the head block and those whose max timestamp is before
+// the timestamp, returning copies of them moved to the expired state so that they can be persisted.
+// Bundles that cannot expire, such as bundles already in a terminal state, are removed without being returned
+func (p *BundlePool) Evict(headBlockNumber, timestamp uint64, at time.Time) []BuilderBundle {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	evicted := []BuilderBundle{}
+	for key, bundle := range p.byHash {
+		if bundle.BlockNumber > headBlockNumber && (bundle.MaxTimestamp == 0 || bundle.MaxTimestamp >= timestamp) {
+			continue
+		}
+
+		p.remove(key)
+		expired := copyBundle(bundle)
+		if err := expired.Transition(BundleStateExpired, at); err != nil {
+			continue
+		}
+		evicted = append(evicted, expired)
+	}
+	return evicted
+}
+
+func (p *BundlePool) remove(key string) bool {
+	bundle, ok := p.byHash[key]
+	if !ok {
+		return false
+	}
+	delete(p.byHash, key)
+	delete(p.byBlock[bundle.BlockNumber], key)
+	if len(p.byBlock[bundle.BlockNumber]) == 0 {
+		delete(p.byBlock, bundle.BlockNumber)
+	}
+	return true
+}
+
+// copyBundle copies the bundle along with its transactions, reverting hashes and transitions,
+// so that the copy shares no slices with the bundle
+func copyBundle(bundle *BuilderBundle) BuilderBundle {
+	res := *bundle
+	if bundle.Txs != nil {
+		res.Txs = append([]*types.Transaction{}, bundle.Txs...)
+	}
+	if bundle.RevertingTxHashes != nil {
+		res.RevertingTxHashes = make([]*common.Hash, len(bundle.RevertingTxHashes))
+		for i, hash := range bundle.RevertingTxHashes {
+			if hash != nil {
+				h := *hash
+				res.RevertingTxHashes[i] = &h
+			}
+		}
+	}
+	if bundle.Transitions != nil {
+		res.Transitions = append([]BundleTransition{}, bundle.Transitions...)
+	}
+	return res
+}
@@ -0,0 +1,97 @@
+package bundles
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestBundlePoolCopies(t *testing.T) {
+	key := testKey(t, 1)
+	bundle := testBundle(t, 100, testTx(t, key, 0, 1))
+	revertingHash := bundle.Txs[0].Hash()
+	bundleRevertingHash := revertingHash
+	bundle.RevertingTxHashes = []*common.Hash{&bundleRevertingHash}
+
+	pool := NewBundlePool()
+	if _, err := pool.Add(bundle); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.Transition(bundle.BundleHash, BundleStatePending, time.Unix(1, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Changing the added bundle does not change the pool
+	bundle.Txs[0] = testTx(t, key, 1, 1)
+	*bundle.RevertingTxHashes[0] = common.Hash{}
+
+	res, ok := pool.Get(bundle.BundleHash)
+	if !ok {
+		t.Fatal("bundle not found")
+	}
+	if res.Txs[0].Hash() != revertingHash || *res.RevertingTxHashes[0] != revertingHash {
+		t.Fatal("pool shares the added bundle")
+	}
+
+	// Changing returned bundles does not change the pool
+	res.Txs[0] = nil
+	*res.RevertingTxHashes[0] = common.Hash{}
+	res.Transitions[0].To = BundleStateFailed
+
+	candidates := pool.Candidates(100, 0)
+	if len(candidates) != 1 {
+		t.Fatalf("expected one candidate, got %d", len(candidates))
+	}
+	candidate := candidates[0]
+	if candidate.Txs[0] == nil || *candidate.RevertingTxHashes[0] != revertingHash || candidate.Transitions[0].To != BundleStatePending {
+		t.Fatal("pool shares the bundle returned by Get")
+	}
+
+	candidate.Txs[0] = nil
+	*candidate.RevertingTxHashes[0] = common.Hash{}
+	candidate.Transitions[0].To = BundleStateFailed
+
+	res, _ = pool.Get(bundle.BundleHash)
+	if res.Txs[0] == nil || *res.RevertingTxHashes[0] != revertingHash || res.Transitions[0].To != BundleStatePending {
+		t.Fatal("pool shares the candidate bundle")
+	}
+}
+
+func TestBundlePoolEvict(t *testing.T) {
+	key := testKey(t, 1)
+	past := testBundle(t, 99, testTx(t, key, 0, 1))
+	expired := testBundle(t, 101, testTx(t, key, 1, 1))
+	expired.MaxTimestamp = 10
+	future := testBundle(t, 101, testTx(t, key, 2, 1))
+	terminal := testBundle(t, 99, testTx(t, key, 3, 1))
+
+	pool := NewBundlePool()
+	for _, bundle := range []*BuilderBundle{past, expired, future, terminal} {
+		if _, err := pool.Add(bundle); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Terminal bundles never stay in the pool, so force one in to check it is not expired
+	pool.byHash[strings.ToLower(terminal.BundleHash)].State = BundleStateIncluded
+
+	evicted := pool.Evict(100, 20, time.Unix(2, 0))
+	if len(evicted) != 2 {
+		t.Fatalf("expected two evicted bundles, got %d", len(evicted))
+	}
+	for _, bundle := range evicted {
+		if bundle.BundleHash != past.BundleHash && bundle.BundleHash != expired.BundleHash {
+			t.Fatalf("unexpected evicted bundle %s", bundle.BundleHash)
+		}
+		if bundle.CurrentState() != BundleStateExpired {
+			t.Fatalf("evicted bundle is %s", bundle.CurrentState())
+		}
+	}
+	if pool.Len() != 1 {
+		t.Fatalf("expected one bundle left, got %d", pool.Len())
+	}
+	if _, ok := pool.Get(future.BundleHash); !ok {
+		t.Fatal("future bundle evicted")
+	}
+}
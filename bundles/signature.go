@@ -0,0 +1,90 @@
+package bundles
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	commonTypes "github.com/bsn-eng/pon-golang-types/common"
+)
+
+// ComputeBundleHash computes the hash of a bundle as eth_sendBundle does, the keccak256 hash of the
+// concatenated hashes of its transactions in order. The bundle metadata is not part of the hash
+func ComputeBundleHash(txs []*types.Transaction) (common.Hash, error) {
+	txHashes := make([]byte, 0, len(txs)*common.HashLength)
+	for i, tx := range txs {
+		if tx == nil {
+			return common.Hash{}, fmt.Errorf("transaction %d missing", i)
+		}
+		txHashes = append(txHashes, tx.Hash().Bytes()...)
+	}
+	return crypto.Keccak256Hash(txHashes), nil
+}
+
+// ComputeBundleHash computes the hash of the bundle from its transactions
+func (b *BuilderBundle) ComputeBundleHash() (common.Hash, error) {
+	return ComputeBundleHash(b.Txs)
+}
+
+// VerifyBundleHash checks that the bundle hash matches the transactions of the bundle
+func (b *BuilderBundle) VerifyBundleHash() error {
+	bundleHash, err := b.ComputeBundleHash()
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(b.BundleHash, bundleHash.Hex()) {
+		return &commonTypes.MismatchError{Mismatches: []commonTypes.FieldMismatch{{
+			Field:    "bundle_hash",
+			Expected: bundleHash.Hex(),
+			Actual:   b.BundleHash,
+		}}}
+	}
+	return nil
+}
+
+// SignBuilderBundle sets the bundle hash of the bundle, then signs the ssz root of the bundle, which covers
+// its transactions and metadata, in the builder domain and sets the builder public key and signature.
+// As the bundle hash only covers the transactions, the metadata is covered by the signature alone
+func SignBuilderBundle(b *BuilderBundle, domain phase0.Domain, secretKey *commonTypes.BLSSecretKey) error {
+	if b == nil {
+		return errors.New("no bundle set")
+	}
+	bundleHash, err := b.ComputeBundleHash()
+	if err != nil {
+		return err
+	}
+	signature, err := commonTypes.SignObject(b, domain, secretKey)
+	if err != nil {
+		return err
+	}
+
+	pubkey := commonTypes.BLSPublicKeyFromSecretKey(secretKey)
+	b.BundleHash = bundleHash.Hex()
+	b.BuilderPubkey = hexutil.Encode(pubkey[:])
+	b.BuilderSignature = hexutil.Encode(signature[:])
+	return nil
+}
+
+// VerifyBuilderSignature checks the bundle hash, then verifies the signature over the bundle by the builder
+// public key in the builder domain
+func (b *BuilderBundle) VerifyBuilderSignature(domain phase0.Domain) (bool, error) {
+	if err := b.VerifyBundleHash(); err != nil {
+		return false, err
+	}
+
+	pubkeyBytes, err := hexutil.Decode(b.BuilderPubkey)
+	if err != nil || len(pubkeyBytes) != phase0.PublicKeyLength {
+		return false, commonTypes.ErrInvalidPubkey
+	}
+	signatureBytes, err := hexutil.Decode(b.BuilderSignature)
+	if err != nil || len(signatureBytes) != phase0.SignatureLength {
+		return false, commonTypes.ErrInvalidSignature
+	}
+	return commonTypes.VerifyObject(b, domain, phase0.BLSPubKey(pubkeyBytes), phase0.BLSSignature(signatureBytes))
+}
@@ -0,0 +1,172 @@
+package bundles
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	commonTypes "github.com/bsn-eng/pon-golang-types/common"
+)
+
+var testChainID = big.NewInt(1)
+
+func testKey(t *testing.T, seed byte) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := crypto.ToECDSA(common.LeftPadBytes([]byte{seed}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func testTx(t *testing.T, key *ecdsa.PrivateKey, nonce uint64, gasPrice int64) *types.Transaction {
+	t.Helper()
+	tx, err := types.SignTx(types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		Gas:      21000,
+		GasPrice: big.NewInt(gasPrice),
+		To:       &common.Address{0x01},
+	}), types.LatestSignerForChainID(testChainID), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+func testBundle(t *testing.T, blockNumber uint64, txs ...*types.Transaction) *BuilderBundle {
+	t.Helper()
+	bundle := &BuilderBundle{
+		Txs:                    txs,
+		BlockNumber:            blockNumber,
+		BundleTransactionCount: uint64(len(txs)),
+	}
+	for _, tx := range txs {
+		bundle.BundleTotalGas += tx.Gas()
+	}
+	bundleHash, err := bundle.ComputeBundleHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle.BundleHash = bundleHash.Hex()
+	return bundle
+}
+
+func TestComputeBundleHash(t *testing.T) {
+	key := testKey(t, 1)
+	first, second := testTx(t, key, 0, 1), testTx(t, key, 1, 1)
+
+	expected := crypto.Keccak256Hash(first.Hash().Bytes(), second.Hash().Bytes())
+	bundle := &BuilderBundle{Txs: []*types.Transaction{first, second}}
+	bundleHash, err := bundle.ComputeBundleHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bundleHash != expected {
+		t.Fatalf("expected %s, got %s", expected, bundleHash)
+	}
+
+	// The hash depends on the order of the transactions
+	reversed, err := ComputeBundleHash([]*types.Transaction{second, first})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reversed == bundleHash {
+		t.Fatal("bundle hash does not depend on the transaction order")
+	}
+	if _, err := ComputeBundleHash([]*types.Transaction{nil}); err == nil {
+		t.Fatal("expected an error for a missing transaction")
+	}
+}
+
+func TestVerifyBundleHash(t *testing.T) {
+	bundle := testBundle(t, 1, testTx(t, testKey(t, 1), 0, 1))
+	if err := bundle.VerifyBundleHash(); err != nil {
+		t.Fatal(err)
+	}
+
+	bundle.BundleHash = common.Hash{0x01}.Hex()
+	var mismatchErr *commonTypes.MismatchError
+	if err := bundle.VerifyBundleHash(); !errors.As(err, &mismatchErr) || mismatchErr.Mismatches[0].Field != "bundle_hash" {
+		t.Fatalf("expected a bundle hash mismatch, got %v", err)
+	}
+}
+
+func TestSignBuilderBundle(t *testing.T) {
+	secretKey, err := commonTypes.BLSSecretKeyFromBytes(common.LeftPadBytes([]byte{0x01}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	domain, err := commonTypes.ComputeBuilderDomain(phase0.Version{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := testKey(t, 1)
+	bundle := &BuilderBundle{Txs: []*types.Transaction{testTx(t, key, 0, 1)}, BlockNumber: 100}
+	if err := SignBuilderBundle(bundle, domain, secretKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := bundle.VerifyBundleHash(); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := bundle.VerifyBuilderSignature(domain); err != nil || !ok {
+		t.Fatalf("signature not verified: %v", err)
+	}
+
+	// The signature covers the bundle metadata
+	bundle.BlockNumber = 101
+	if ok, _ := bundle.VerifyBuilderSignature(domain); ok {
+		t.Fatal("signature verified for a changed block number")
+	}
+	bundle.BlockNumber = 100
+
+	// Changed transactions fail the bundle hash check before the signature
+	signedTx := bundle.Txs[0]
+	bundle.Txs[0] = testTx(t, key, 1, 1)
+	var mismatchErr *commonTypes.MismatchError
+	if _, err := bundle.VerifyBuilderSignature(domain); !errors.As(err, &mismatchErr) {
+		t.Fatalf("expected a bundle hash mismatch, got %v", err)
+	}
+	bundle.Txs[0] = signedTx
+
+	bundle.BuilderPubkey = "0x01"
+	if _, err := bundle.VerifyBuilderSignature(domain); !errors.Is(err, commonTypes.ErrInvalidPubkey) {
+		t.Fatalf("expected an invalid public key error, got %v", err)
+	}
+	if err := SignBuilderBundle(nil, domain, secretKey); err == nil {
+		t.Fatal("expected an error for a missing bundle")
+	}
+}
+
+func TestSignBuilderBundleMissingRevertingTxHash(t *testing.T) {
+	secretKey, err := commonTypes.BLSSecretKeyFromBytes(common.LeftPadBytes([]byte{0x01}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	domain, err := commonTypes.ComputeBuilderDomain(phase0.Version{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bundle := testBundle(t, 100, testTx(t, testKey(t, 1), 0, 1))
+	bundle.RevertingTxHashes = []*common.Hash{nil}
+	if err := SignBuilderBundle(bundle, domain, secretKey); err == nil {
+		t.Fatal("expected an error for a missing reverting transaction hash")
+	}
+
+	revertingTxHash := bundle.Txs[0].Hash()
+	bundle.RevertingTxHashes = []*common.Hash{&revertingTxHash}
+	if err := SignBuilderBundle(bundle, domain, secretKey); err != nil {
+		t.Fatal(err)
+	}
+	bundle.RevertingTxHashes[0] = nil
+	if _, err := bundle.VerifyBuilderSignature(domain); err == nil {
+		t.Fatal("expected an error for a missing reverting transaction hash")
+	}
+}
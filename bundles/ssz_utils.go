@@ -1,6 +1,8 @@
 package bundles
 
 import (
+	"fmt"
+
 	ssz "github.com/ferranbt/fastssz"
 )
 
@@ -15,6 +17,9 @@ func (b *BuilderBundle) HashTreeRootWith(hh ssz.HashWalker) (err error) {
 
 	// Field (0) 'Txs'
 	for i := 0; i < len(b.Txs); i++ {
+		if b.Txs[i] == nil {
+			return fmt.Errorf("transaction %d missing", i)
+		}
 		bytes, err := b.Txs[i].MarshalBinary()
 		if err != nil {
 			return err
@@ -33,6 +38,9 @@ func (b *BuilderBundle) HashTreeRootWith(hh ssz.HashWalker) (err error) {
 
 	// Field (4) 'RevertingTxHashes'
 	for i := 0; i < len(b.RevertingTxHashes); i++ {
+		if b.RevertingTxHashes[i] == nil {
+			return fmt.Errorf("reverting transaction hash %d missing", i)
+		}
 		hh.PutBytes(b.RevertingTxHashes[i][:])
 	}
 
@@ -2,7 +2,6 @@ package bundles
 
 import (
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -14,12 +13,12 @@ type BuilderBundleEntry struct {
 	ID         string    `db:"id" json:"id"`
 	InsertedAt time.Time `db:"inserted_at" json:"inserted_at"`
 
-	BundleHash        string `db:"bundle_hash"`
-	Txs               string `db:"txs" json:"txs,omitempty"`
-	BlockNumber       uint64 `db:"block_number" json:"block_number,string"`
-	MinTimestamp      uint64 `db:"min_timestamp" json:"min_timestamp,string,omitempty"`
-	MaxTimestamp      uint64 `db:"max_timestamp" json:"max_timestamp,string,omitempty"`
-	RevertingTxHashes string `db:"reverting_tx_hashes" json:"reverting_tx_hashes,omitempty"` // Comma-separated list of hex-encoded hashes
+	BundleHash        string  `db:"bundle_hash"`
+	Txs               HexList `db:"txs" json:"txs,omitempty"`
+	BlockNumber       uint64  `db:"block_number" json:"block_number,string"`
+	MinTimestamp      uint64  `db:"min_timestamp" json:"min_timestamp,string,omitempty"`
+	MaxTimestamp      uint64  `db:"max_timestamp" json:"max_timestamp,string,omitempty"`
+	RevertingTxHashes HexList `db:"reverting_tx_hashes" json:"reverting_tx_hashes,omitempty"`
 
 	BuilderPubkey    string `db:"builder_pubkey"`
 	BuilderSignature string `db:"builder_signature"`
@@ -27,10 +26,14 @@ type BuilderBundleEntry struct {
 	BundleTransactionCount uint64   `db:"bundle_transaction_count" json:"bundle_transaction_count,string"`
 	BundleTotalGas         uint64 `db:"bundle_total_gas" json:"bundle_total_gas,string"`
 
+	// Added and Error are derived from State, and only used for entries stored before State
 	Added        bool   `db:"added"`
 	Error        bool   `db:"error"`
 	ErrorMessage string `db:"error_message"`
 
+	State       string `db:"state" json:"state"`
+	Transitions string `db:"transitions" json:"transitions,omitempty"` // JSON encoded list of state transitions
+
 	FailedRetryCount uint64 `db:"failed_retry_count" json:"failed_retry_count,string"`
 }
 
@@ -54,47 +57,58 @@ type BuilderBundle struct {
 
 	BundleDateTime time.Time
 
-	Added        bool
-	Error        bool
+	State       BundleState
+	Transitions []BundleTransition
+
 	ErrorMessage string
 
 	FailedRetryCount uint64
-
-	Adding   bool
-	Included bool
 }
 
 func BuilderBundleToEntry(b *BuilderBundle) (*BuilderBundleEntry, error) {
 
-	var txList []string
-	for _, tx := range b.Txs {
+	txList := HexList{}
+	for i, tx := range b.Txs {
+		if tx == nil {
+			return nil, fmt.Errorf("tx %d missing", i)
+		}
 		txBytes, err := tx.MarshalBinary()
 		if err != nil {
-			return nil, fmt.Errorf("error marshalling tx: %v", err)
+			return nil, fmt.Errorf("error marshalling tx %d: %v", i, err)
 		}
 		txList = append(txList, hexutil.Encode(txBytes))
 	}
 
-	var revertingTxHashes []string
-	for _, txHash := range b.RevertingTxHashes {
+	revertingTxHashes := HexList{}
+	for i, txHash := range b.RevertingTxHashes {
+		if txHash == nil {
+			return nil, fmt.Errorf("reverting tx hash %d missing", i)
+		}
 		revertingTxHashes = append(revertingTxHashes, txHash.Hex())
 	}
 
+	transitions, err := encodeTransitions(b.Transitions)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling transitions: %v", err)
+	}
+
 	return &BuilderBundleEntry{
 		ID:                     b.ID,
 		BundleHash:             b.BundleHash,
-		Txs:                    strings.Join(txList, ","),
+		Txs:                    txList,
 		BlockNumber:            b.BlockNumber,
 		MinTimestamp:           b.MinTimestamp,
 		MaxTimestamp:           b.MaxTimestamp,
-		RevertingTxHashes:      strings.Join(revertingTxHashes, ","),
+		RevertingTxHashes:      revertingTxHashes,
 		BuilderPubkey:          b.BuilderPubkey,
 		BuilderSignature:       b.BuilderSignature,
 		BundleTransactionCount: b.BundleTransactionCount,
 		BundleTotalGas:         b.BundleTotalGas,
-		Added:                  b.Added,
-		Error:                  b.Error,
+		Added:                  b.IsAdded(),
+		Error:                  b.IsFailed(),
 		ErrorMessage:           b.ErrorMessage,
+		State:                  string(b.CurrentState()),
+		Transitions:            transitions,
 		FailedRetryCount:       b.FailedRetryCount,
 		InsertedAt:             b.BundleDateTime,
 	}, nil
@@ -103,33 +117,37 @@ func BuilderBundleToEntry(b *BuilderBundle) (*BuilderBundleEntry, error) {
 func BuilderBundleEntryToBundle(b *BuilderBundleEntry) (*BuilderBundle, error) {
 
 	var txs []*types.Transaction
-	txList := strings.Split(b.Txs, ",")
-	for _, txBytesEncoded := range txList {
-		if txBytesEncoded == "" {
-			continue
-		}
+	for i, txBytesEncoded := range b.Txs {
 		txBytes, err := hexutil.Decode(txBytesEncoded)
 		if err != nil {
-			return nil, fmt.Errorf("error decoding tx bytes: %v", err)
+			return nil, fmt.Errorf("error decoding tx %d bytes: %v", i, err)
 		}
 		var tx types.Transaction
 		err = tx.UnmarshalBinary(txBytes)
 		if err != nil {
-			return nil, fmt.Errorf("error unmarshalling tx: %v", err)
+			return nil, fmt.Errorf("error unmarshalling tx %d: %v", i, err)
 		}
 		txs = append(txs, &tx)
 	}
 
 	var revertingTxHashes []*common.Hash
-	revertingTxHashesList := strings.Split(b.RevertingTxHashes, ",")
-	for _, txHash := range revertingTxHashesList {
-		if txHash == "" {
-			continue
+	for i, txHash := range b.RevertingTxHashes {
+		var hash common.Hash
+		if err := hash.UnmarshalText([]byte(txHash)); err != nil {
+			return nil, fmt.Errorf("error decoding reverting tx hash %d: %v", i, err)
 		}
-		hash := common.HexToHash(txHash)
 		revertingTxHashes = append(revertingTxHashes, &hash)
 	}
 
+	state, err := entryState(b)
+	if err != nil {
+		return nil, err
+	}
+	transitions, err := decodeTransitions(b.Transitions)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling transitions: %v", err)
+	}
+
 	return &BuilderBundle{
 		ID:                     b.ID,
 		BundleHash:             b.BundleHash,
@@ -142,12 +160,10 @@ func BuilderBundleEntryToBundle(b *BuilderBundleEntry) (*BuilderBundle, error) {
 		BuilderSignature:       b.BuilderSignature,
 		BundleTransactionCount: b.BundleTransactionCount,
 		BundleTotalGas:         b.BundleTotalGas,
-		Added:                  b.Added,
-		Error:                  b.Error,
+		State:                  state,
+		Transitions:            transitions,
 		ErrorMessage:           b.ErrorMessage,
 		FailedRetryCount:       b.FailedRetryCount,
 		BundleDateTime:         b.InsertedAt,
-		Adding:                 false,
-		Included:               false,
 	}, nil
 }
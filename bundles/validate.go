@@ -0,0 +1,99 @@
+package bundles
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	ErrNoTransactions           = errors.New("bundle has no transactions")
+	ErrInvalidTimestampRange    = errors.New("min timestamp is after max timestamp")
+	ErrBlockNumberMismatch      = errors.New("bundle targets another block")
+	ErrTimestampOutOfRange      = errors.New("slot timestamp outside bundle timestamp range")
+	ErrUnknownRevertingTx       = errors.New("reverting tx hash not in bundle")
+	ErrTransactionCountMismatch = errors.New("bundle transaction count mismatch")
+	ErrTotalGasMismatch         = errors.New("bundle total gas mismatch")
+	ErrInvalidSender            = errors.New("transaction sender cannot be recovered")
+)
+
+// Bundle Validation Parameters, zero values skip the checks that need them
+type BundleValidationOpts struct {
+	// BlockNumber is the execution block number built in the slot
+	BlockNumber uint64
+	// SlotTimestamp is the timestamp of the execution block built in the slot
+	SlotTimestamp uint64
+	// ChainID is used to recover the senders of the transactions
+	ChainID *big.Int
+}
+
+// Validate checks the bundle for consistency and against the slot, returning every problem
+// found joined into one error. A zero min or max timestamp leaves that side of the range open
+func (b *BuilderBundle) Validate(opts BundleValidationOpts) error {
+	var errs []error
+
+	if len(b.Txs) == 0 {
+		errs = append(errs, ErrNoTransactions)
+	}
+
+	if b.MinTimestamp != 0 && b.MaxTimestamp != 0 && b.MinTimestamp > b.MaxTimestamp {
+		errs = append(errs, fmt.Errorf("%w: %d > %d", ErrInvalidTimestampRange, b.MinTimestamp, b.MaxTimestamp))
+	}
+
+	if opts.BlockNumber != 0 && b.BlockNumber != opts.BlockNumber {
+		errs = append(errs, fmt.Errorf("%w: bundle block %d, slot block %d", ErrBlockNumberMismatch, b.BlockNumber, opts.BlockNumber))
+	}
+
+	if opts.SlotTimestamp != 0 {
+		if b.MinTimestamp != 0 && opts.SlotTimestamp < b.MinTimestamp {
+			errs = append(errs, fmt.Errorf("%w: %d before min timestamp %d", ErrTimestampOutOfRange, opts.SlotTimestamp, b.MinTimestamp))
+		}
+		if b.MaxTimestamp != 0 && opts.SlotTimestamp > b.MaxTimestamp {
+			errs = append(errs, fmt.Errorf("%w: %d after max timestamp %d", ErrTimestampOutOfRange, opts.SlotTimestamp, b.MaxTimestamp))
+		}
+	}
+
+	var signer types.Signer
+	if opts.ChainID != nil {
+		signer = types.LatestSignerForChainID(opts.ChainID)
+	}
+
+	txHashes := make(map[common.Hash]bool, len(b.Txs))
+	totalGas := uint64(0)
+	for i, tx := range b.Txs {
+		if tx == nil {
+			errs = append(errs, fmt.Errorf("transaction %d missing", i))
+			continue
+		}
+		txHashes[tx.Hash()] = true
+		totalGas += tx.Gas()
+
+		if signer != nil {
+			if _, err := types.Sender(signer, tx); err != nil {
+				errs = append(errs, fmt.Errorf("%w: transaction %d %s: %v", ErrInvalidSender, i, tx.Hash().Hex(), err))
+			}
+		}
+	}
+
+	for _, txHash := range b.RevertingTxHashes {
+		if txHash == nil {
+			errs = append(errs, fmt.Errorf("%w: empty hash", ErrUnknownRevertingTx))
+			continue
+		}
+		if !txHashes[*txHash] {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrUnknownRevertingTx, txHash.Hex()))
+		}
+	}
+
+	if b.BundleTransactionCount != uint64(len(b.Txs)) {
+		errs = append(errs, fmt.Errorf("%w: declared %d, decoded %d", ErrTransactionCountMismatch, b.BundleTransactionCount, len(b.Txs)))
+	}
+	if b.BundleTotalGas != totalGas {
+		errs = append(errs, fmt.Errorf("%w: declared %d, decoded %d", ErrTotalGasMismatch, b.BundleTotalGas, totalGas))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,83 @@
+package bundles
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestValidate(t *testing.T) {
+	key := testKey(t, 1)
+	bundle := testBundle(t, 100, testTx(t, key, 0, 1), testTx(t, key, 1, 1))
+	bundle.MinTimestamp, bundle.MaxTimestamp = 10, 20
+	revertingHash := bundle.Txs[1].Hash()
+	bundle.RevertingTxHashes = []*common.Hash{&revertingHash}
+
+	opts := BundleValidationOpts{BlockNumber: 100, SlotTimestamp: 15, ChainID: testChainID}
+	if err := bundle.Validate(opts); err != nil {
+		t.Fatal(err)
+	}
+	// Zero values skip the checks against the slot
+	if err := bundle.Validate(BundleValidationOpts{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateErrors(t *testing.T) {
+	key := testKey(t, 1)
+	opts := BundleValidationOpts{BlockNumber: 100, SlotTimestamp: 15, ChainID: testChainID}
+
+	tests := []struct {
+		name     string
+		modify   func(bundle *BuilderBundle)
+		opts     BundleValidationOpts
+		expected []error
+	}{
+		{"no transactions", func(b *BuilderBundle) {
+			b.Txs, b.BundleTransactionCount, b.BundleTotalGas = nil, 0, 0
+		}, opts, []error{ErrNoTransactions}},
+		{"timestamp range", func(b *BuilderBundle) {
+			b.MinTimestamp, b.MaxTimestamp = 20, 10
+		}, BundleValidationOpts{}, []error{ErrInvalidTimestampRange}},
+		{"block number", func(b *BuilderBundle) {
+			b.BlockNumber = 99
+		}, opts, []error{ErrBlockNumberMismatch}},
+		{"before min timestamp", func(b *BuilderBundle) {
+			b.MinTimestamp = 16
+		}, opts, []error{ErrTimestampOutOfRange}},
+		{"after max timestamp", func(b *BuilderBundle) {
+			b.MaxTimestamp = 14
+		}, opts, []error{ErrTimestampOutOfRange}},
+		{"unknown reverting tx", func(b *BuilderBundle) {
+			b.RevertingTxHashes = []*common.Hash{{0x01}, nil}
+		}, opts, []error{ErrUnknownRevertingTx}},
+		{"other chain", func(b *BuilderBundle) {}, BundleValidationOpts{ChainID: big.NewInt(2)}, []error{ErrInvalidSender}},
+		{"declared counts", func(b *BuilderBundle) {
+			b.BundleTransactionCount, b.BundleTotalGas = 2, 1
+		}, opts, []error{ErrTransactionCountMismatch, ErrTotalGasMismatch}},
+		{"every problem", func(b *BuilderBundle) {
+			b.BlockNumber, b.MaxTimestamp, b.BundleTotalGas = 99, 14, 1
+		}, opts, []error{ErrBlockNumberMismatch, ErrTimestampOutOfRange, ErrTotalGasMismatch}},
+	}
+	for _, test := range tests {
+		bundle := testBundle(t, 100, testTx(t, key, 0, 1))
+		test.modify(bundle)
+		err := bundle.Validate(test.opts)
+		if err == nil {
+			t.Fatalf("%s: expected an error", test.name)
+		}
+		for _, expected := range test.expected {
+			if !errors.Is(err, expected) {
+				t.Fatalf("%s: expected %v in %v", test.name, expected, err)
+			}
+		}
+	}
+
+	bundle := &BuilderBundle{Txs: []*types.Transaction{nil}, BundleTransactionCount: 1}
+	if err := bundle.Validate(BundleValidationOpts{}); err == nil {
+		t.Fatal("expected an error for a missing transaction")
+	}
+}
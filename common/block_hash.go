@@ -0,0 +1,205 @@
+package common
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// executionBlockHeader is the RLP layout of an execution layer block header. Fields added
+// by later forks are optional so that headers of earlier forks encode without them
+type executionBlockHeader struct {
+	ParentHash       common.Hash
+	UncleHash        common.Hash
+	Coinbase         common.Address
+	Root             common.Hash
+	TxHash           common.Hash
+	ReceiptHash      common.Hash
+	Bloom            types.Bloom
+	Difficulty       *big.Int
+	Number           *big.Int
+	GasLimit         uint64
+	GasUsed          uint64
+	Time             uint64
+	Extra            []byte
+	MixDigest        common.Hash
+	Nonce            types.BlockNonce
+	BaseFee          *big.Int     `rlp:"optional"`
+	WithdrawalsHash  *common.Hash `rlp:"optional"`
+	BlobGasUsed      *uint64      `rlp:"optional"`
+	ExcessBlobGas    *uint64      `rlp:"optional"`
+	ParentBeaconRoot *common.Hash `rlp:"optional"`
+	RequestsHash     *common.Hash `rlp:"optional"`
+}
+
+// ComputeExecutionBlockHash RLP encodes the execution block header implied by the payload for the given fork
+// version and returns its keccak hash. The parent beacon block root is only part of the header from deneb,
+// and the execution requests of the payload are only used from electra.
+func ComputeExecutionBlockHash(version spec.DataVersion, payload BaseExecutionPayload, parentBeaconBlockRoot phase0.Root) (phase0.Hash32, error) {
+	header, err := executionBlockHeaderFromPayload(version, payload, parentBeaconBlockRoot)
+	if err != nil {
+		return phase0.Hash32{}, err
+	}
+
+	encoded, err := rlp.EncodeToBytes(header)
+	if err != nil {
+		return phase0.Hash32{}, err
+	}
+
+	return phase0.Hash32(crypto.Keccak256Hash(encoded)), nil
+}
+
+// VerifyExecutionBlockHash recomputes the execution block hash of the payload and returns a MismatchError if
+// it does not match the block hash of the payload
+func VerifyExecutionBlockHash(version spec.DataVersion, payload BaseExecutionPayload, parentBeaconBlockRoot phase0.Root) error {
+	blockHash, err := ComputeExecutionBlockHash(version, payload, parentBeaconBlockRoot)
+	if err != nil {
+		return err
+	}
+	if blockHash != payload.BlockHash {
+		return &MismatchError{Mismatches: []FieldMismatch{{
+			Field:    "block_hash",
+			Expected: fmt.Sprintf("%#x", blockHash),
+			Actual:   fmt.Sprintf("%#x", payload.BlockHash),
+		}}}
+	}
+	return nil
+}
+
+func executionBlockHeaderFromPayload(version spec.DataVersion, payload BaseExecutionPayload, parentBeaconBlockRoot phase0.Root) (*executionBlockHeader, error) {
+	if version < spec.DataVersionBellatrix {
+		return nil, fmt.Errorf("unsupported execution payload version %s", version)
+	}
+	if payload.BaseFeePerGas == nil {
+		return nil, errors.New("no base fee per gas set")
+	}
+
+	header := &executionBlockHeader{
+		ParentHash:  common.Hash(payload.ParentHash),
+		UncleHash:   types.EmptyUncleHash,
+		Coinbase:    common.Address(payload.FeeRecipient),
+		Root:        common.Hash(payload.StateRoot),
+		TxHash:      computeTransactionsTrieRoot(payload.Transactions),
+		ReceiptHash: common.Hash(payload.ReceiptsRoot),
+		Bloom:       types.Bloom(payload.LogsBloom),
+		Difficulty:  big.NewInt(0),
+		Number:      new(big.Int).SetUint64(payload.BlockNumber),
+		GasLimit:    payload.GasLimit,
+		GasUsed:     payload.GasUsed,
+		Time:        payload.Timestamp,
+		Extra:       payload.ExtraData,
+		MixDigest:   common.Hash(payload.PrevRandao),
+		BaseFee:     payload.BaseFeePerGas.ToBig(),
+	}
+
+	if version >= spec.DataVersionCapella {
+		withdrawalsHash, err := computeWithdrawalsTrieRoot(payload.Withdrawals)
+		if err != nil {
+			return nil, err
+		}
+		header.WithdrawalsHash = &withdrawalsHash
+	}
+
+	if version >= spec.DataVersionDeneb {
+		blobGasUsed := payload.BlobGasUsed
+		excessBlobGas := payload.ExcessBlobGas
+		beaconRoot := common.Hash(parentBeaconBlockRoot)
+		header.BlobGasUsed = &blobGasUsed
+		header.ExcessBlobGas = &excessBlobGas
+		header.ParentBeaconRoot = &beaconRoot
+	}
+
+	if version >= spec.DataVersionElectra {
+		if payload.ExecutionRequests == nil {
+			return nil, errors.New("no execution requests set")
+		}
+		requestsHash, err := computeRequestsHash(payload.ExecutionRequests)
+		if err != nil {
+			return nil, err
+		}
+		header.RequestsHash = &requestsHash
+	}
+
+	return header, nil
+}
+
+// rawTransactions is the list of encoded transactions of a payload, which are
+// hashed into the trie as they are without decoding them
+type rawTransactions []bellatrix.Transaction
+
+func (t rawTransactions) Len() int { return len(t) }
+
+func (t rawTransactions) EncodeIndex(i int, w *bytes.Buffer) {
+	w.Write(t[i])
+}
+
+func computeTransactionsTrieRoot(transactions []bellatrix.Transaction) common.Hash {
+	return types.DeriveSha(rawTransactions(transactions), new(listHasher))
+}
+
+func computeWithdrawalsTrieRoot(withdrawals []*capella.Withdrawal) (common.Hash, error) {
+	gethWithdrawals, err := CapellaWithdrawalsToGeth(withdrawals)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return types.DeriveSha(gethWithdrawals, new(listHasher)), nil
+}
+
+// computeRequestsHash computes the EIP-7685 commitment to the execution requests, where each request
+// type with a non-empty list contributes the hash of its type byte followed by its encoded requests
+func computeRequestsHash(requests *electra.ExecutionRequests) (common.Hash, error) {
+	var requestsData [][]byte
+
+	deposits := []byte{0x00}
+	for _, r := range requests.Deposits {
+		data, err := r.MarshalSSZ()
+		if err != nil {
+			return common.Hash{}, err
+		}
+		deposits = append(deposits, data...)
+	}
+	requestsData = append(requestsData, deposits)
+
+	withdrawals := []byte{0x01}
+	for _, r := range requests.Withdrawals {
+		data, err := r.MarshalSSZ()
+		if err != nil {
+			return common.Hash{}, err
+		}
+		withdrawals = append(withdrawals, data...)
+	}
+	requestsData = append(requestsData, withdrawals)
+
+	consolidations := []byte{0x02}
+	for _, r := range requests.Consolidations {
+		data, err := r.MarshalSSZ()
+		if err != nil {
+			return common.Hash{}, err
+		}
+		consolidations = append(consolidations, data...)
+	}
+	requestsData = append(requestsData, consolidations)
+
+	hasher := sha256.New()
+	for _, data := range requestsData {
+		if len(data) == 1 {
+			continue
+		}
+		requestHash := sha256.Sum256(data)
+		hasher.Write(requestHash[:])
+	}
+
+	return common.Hash(hasher.Sum(nil)), nil
+}
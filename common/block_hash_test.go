@@ -0,0 +1,126 @@
+package common
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	bellatrix "github.com/attestantio/go-eth2-client/spec/bellatrix"
+	electra "github.com/attestantio/go-eth2-client/spec/electra"
+	phase0 "github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// testElectraBlock loads an electra devnet block with 92 transactions and 14 withdrawals, whose execution
+// payload block hash was computed by the execution client that built it
+func testElectraBlock(t *testing.T) *electra.SignedBeaconBlock {
+	t.Helper()
+	data, err := os.ReadFile("testdata/electra_signed_beacon_block.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := &electra.SignedBeaconBlock{}
+	if err := block.UnmarshalJSON(data); err != nil {
+		t.Fatal(err)
+	}
+	return block
+}
+
+func TestComputeExecutionBlockHash(t *testing.T) {
+	block := testElectraBlock(t)
+	payload := VersionedExecutionPayload{
+		Electra:           block.Message.Body.ExecutionPayload,
+		ExecutionRequests: block.Message.Body.ExecutionRequests,
+	}
+	base, err := payload.ToBaseExecutionPayload()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The payload is hashed as the header of each fork, where the hashes before electra
+	// were computed from the same payload by the go-ethereum header implementation
+	tests := []struct {
+		version  spec.DataVersion
+		expected string
+	}{
+		{spec.DataVersionBellatrix, "0xd889f588f2916d71abb9820c04eb55c0dc13ef734f378686ccf2fdd838ef54b8"},
+		{spec.DataVersionCapella, "0xdbbbc9343b8ed13ced86bce6d97ad31583fe45797d8a0e8f2b08a11fbe9b212b"},
+		{spec.DataVersionDeneb, "0xcd9d3571557bd47c2f620d9eebea6fed683b09d6eabc6576ba17f60da9ddf24e"},
+		{spec.DataVersionElectra, "0x4fa54f03fabaf49c1552aef3be137c856b29faf61c95147bb6757b1ab6b0a9d0"},
+	}
+	for _, test := range tests {
+		blockHash, err := ComputeExecutionBlockHash(test.version, base, block.Message.ParentRoot)
+		if err != nil {
+			t.Fatalf("%s: %v", test.version, err)
+		}
+		if blockHash.String() != test.expected {
+			t.Fatalf("%s: expected %s, got %s", test.version, test.expected, blockHash)
+		}
+	}
+}
+
+func TestVerifyExecutionBlockHash(t *testing.T) {
+	block := testElectraBlock(t)
+	payload := VersionedExecutionPayload{
+		Electra:           block.Message.Body.ExecutionPayload,
+		ExecutionRequests: block.Message.Body.ExecutionRequests,
+	}
+	base, err := payload.ToBaseExecutionPayload()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := VerifyExecutionBlockHash(spec.DataVersionElectra, base, block.Message.ParentRoot); err != nil {
+		t.Fatal(err)
+	}
+
+	err = VerifyExecutionBlockHash(spec.DataVersionElectra, base, phase0.Root{})
+	var mismatchErr *MismatchError
+	if !errors.As(err, &mismatchErr) || mismatchErr.Mismatches[0].Field != "block_hash" {
+		t.Fatalf("expected a block hash mismatch, got %v", err)
+	}
+
+	base.ExecutionRequests = nil
+	if err := VerifyExecutionBlockHash(spec.DataVersionElectra, base, block.Message.ParentRoot); err == nil {
+		t.Fatal("expected an error for missing execution requests")
+	}
+}
+
+func TestComputeTransactionsTrieRootEmpty(t *testing.T) {
+	// The root of the empty trie
+	expected := "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421"
+	if root := computeTransactionsTrieRoot(nil); root.Hex() != expected {
+		t.Fatalf("expected %s, got %s", expected, root.Hex())
+	}
+	root, err := computeWithdrawalsTrieRoot(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root.Hex() != expected {
+		t.Fatalf("expected %s, got %s", expected, root.Hex())
+	}
+}
+
+func TestComputeTransactionsTrieRoot(t *testing.T) {
+	// Roots computed with the go-ethereum stack trie, covering leaves, extensions and branches
+	// holding inline and hashed children
+	expected := map[int]string{
+		1:   "0x7da536f7df63a0dfb481590e53be0e3063d9b798925cc3d479a3eb3155d0b394",
+		2:   "0x47a291e1f0920ea2b564d3d828c0f3a4691bc9f2b15813c5a3a3e7e3d6bd17ef",
+		16:  "0x76227136489f4f71c36761df83eb3186e75d047666ada0a921c33cc8facc0b39",
+		17:  "0xfd0acc349d10c623311c31910cd43892a036fc692b96340a9ae1ff4225ef8feb",
+		128: "0x2695ae12f944ae911270fc0457218c115d5eda80c662f5a1de95ca0737c37da5",
+		129: "0x348c5a76e430d703e4d02e80941e91081af2211e693d506e08efa096c2566ac4",
+		300: "0xbf867fe948c8078a13533331868c82048c1a9acc7f36d2170117d9ddf235e1e8",
+	}
+	for count, root := range expected {
+		transactions := make([]bellatrix.Transaction, count)
+		for i := range transactions {
+			transactions[i] = bytes.Repeat([]byte{byte(i)}, i%40+1)
+		}
+		if actual := computeTransactionsTrieRoot(transactions); actual.Hex() != root {
+			t.Fatalf("expected %s for %d transactions, got %s", root, count, actual.Hex())
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	denebApi "github.com/attestantio/go-builder-client/api/deneb"
+	deneb "github.com/attestantio/go-eth2-client/spec/deneb"
+	electra "github.com/attestantio/go-eth2-client/spec/electra"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/goccy/go-yaml"
+)
+
+// electraExecutionPayload is the encoding of an Electra execution payload, which carries the
+// execution requests produced alongside the payload as the Electra payload container does not hold them
+type electraExecutionPayload struct {
+	ExecutionPayload  *deneb.ExecutionPayload
+	ExecutionRequests *electra.ExecutionRequests
+}
+
+type electraExecutionPayloadJSON struct {
+	ExecutionPayload  *deneb.ExecutionPayload    `json:"execution_payload"`
+	ExecutionRequests *electra.ExecutionRequests `json:"execution_requests"`
+}
+
+// electraExecutionPayloadAndBlobsBundle is the encoding of an Electra execution payload with its
+// blobs bundle and execution requests
+type electraExecutionPayloadAndBlobsBundle struct {
+	ExecutionPayload  *deneb.ExecutionPayload
+	BlobsBundle       *denebApi.BlobsBundle
+	ExecutionRequests *electra.ExecutionRequests
+}
+
+type electraExecutionPayloadAndBlobsBundleJSON struct {
+	ExecutionPayload  *deneb.ExecutionPayload    `json:"execution_payload"`
+	BlobsBundle       *denebApi.BlobsBundle      `json:"blobs_bundle"`
+	ExecutionRequests *electra.ExecutionRequests `json:"execution_requests"`
+}
+
+func (e *electraExecutionPayload) check() error {
+	if e.ExecutionPayload == nil {
+		return errors.New("no ExecutionPayload set")
+	}
+	if e.ExecutionRequests == nil {
+		return errors.New("no ExecutionRequests set")
+	}
+	return nil
+}
+
+func (e *electraExecutionPayload) MarshalJSON() ([]byte, error) {
+	if err := e.check(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(&electraExecutionPayloadJSON{
+		ExecutionPayload:  e.ExecutionPayload,
+		ExecutionRequests: e.ExecutionRequests,
+	})
+}
+
+func (e *electraExecutionPayload) UnmarshalJSON(input []byte) error {
+	var data electraExecutionPayloadJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return err
+	}
+	res := electraExecutionPayload{
+		ExecutionPayload:  data.ExecutionPayload,
+		ExecutionRequests: data.ExecutionRequests,
+	}
+	if err := res.check(); err != nil {
+		return err
+	}
+	*e = res
+	return nil
+}
+
+func (e *electraExecutionPayload) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(e)
+}
+
+func (e *electraExecutionPayload) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if err = e.check(); err != nil {
+		return
+	}
+	dst = buf
+	offset := int(8)
+
+	// Offset (0) 'ExecutionPayload'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += e.ExecutionPayload.SizeSSZ()
+
+	// Offset (1) 'ExecutionRequests'
+	dst = ssz.WriteOffset(dst, offset)
+
+	// Field (0) 'ExecutionPayload'
+	if dst, err = e.ExecutionPayload.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (1) 'ExecutionRequests'
+	dst, err = e.ExecutionRequests.MarshalSSZTo(dst)
+	return
+}
+
+func (e *electraExecutionPayload) SizeSSZ() (size int) {
+	size = 8
+	if e.ExecutionPayload != nil {
+		size += e.ExecutionPayload.SizeSSZ()
+	}
+	if e.ExecutionRequests == nil {
+		return size + new(electra.ExecutionRequests).SizeSSZ()
+	}
+	return size + e.ExecutionRequests.SizeSSZ()
+}
+
+func (e *electraExecutionPayload) UnmarshalSSZ(buf []byte) error {
+	offsets, err := readOffsets(buf, 2)
+	if err != nil {
+		return err
+	}
+
+	res := electraExecutionPayload{
+		ExecutionPayload:  new(deneb.ExecutionPayload),
+		ExecutionRequests: new(electra.ExecutionRequests),
+	}
+	if err := res.ExecutionPayload.UnmarshalSSZ(buf[offsets[0]:offsets[1]]); err != nil {
+		return err
+	}
+	if err := res.ExecutionRequests.UnmarshalSSZ(buf[offsets[1]:]); err != nil {
+		return err
+	}
+	*e = res
+	return nil
+}
+
+func (e *electraExecutionPayload) MarshalYAML() ([]byte, error) {
+	data, err := e.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return yaml.JSONToYAML(data)
+}
+
+func (e *electraExecutionPayload) UnmarshalYAML(input []byte) error {
+	data, err := yaml.YAMLToJSON(input)
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalJSON(data)
+}
+
+func (e *electraExecutionPayloadAndBlobsBundle) check() error {
+	if e.ExecutionPayload == nil {
+		return errors.New("no ExecutionPayload set")
+	}
+	if e.BlobsBundle == nil {
+		return errors.New("no BlobsBundle set")
+	}
+	if e.ExecutionRequests == nil {
+		return errors.New("no ExecutionRequests set")
+	}
+	return nil
+}
+
+func (e *electraExecutionPayloadAndBlobsBundle) MarshalJSON() ([]byte, error) {
+	if err := e.check(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(&electraExecutionPayloadAndBlobsBundleJSON{
+		ExecutionPayload:  e.ExecutionPayload,
+		BlobsBundle:       e.BlobsBundle,
+		ExecutionRequests: e.ExecutionRequests,
+	})
+}
+
+func (e *electraExecutionPayloadAndBlobsBundle) UnmarshalJSON(input []byte) error {
+	var data electraExecutionPayloadAndBlobsBundleJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return err
+	}
+	res := electraExecutionPayloadAndBlobsBundle{
+		ExecutionPayload:  data.ExecutionPayload,
+		BlobsBundle:       data.BlobsBundle,
+		ExecutionRequests: data.ExecutionRequests,
+	}
+	if err := res.check(); err != nil {
+		return err
+	}
+	*e = res
+	return nil
+}
+
+func (e *electraExecutionPayloadAndBlobsBundle) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(e)
+}
+
+func (e *electraExecutionPayloadAndBlobsBundle) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if err = e.check(); err != nil {
+		return
+	}
+	dst = buf
+	offset := int(12)
+
+	// Offset (0) 'ExecutionPayload'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += e.ExecutionPayload.SizeSSZ()
+
+	// Offset (1) 'BlobsBundle'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += e.BlobsBundle.SizeSSZ()
+
+	// Offset (2) 'ExecutionRequests'
+	dst = ssz.WriteOffset(dst, offset)
+
+	// Field (0) 'ExecutionPayload'
+	if dst, err = e.ExecutionPayload.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (1) 'BlobsBundle'
+	if dst, err = e.BlobsBundle.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (2) 'ExecutionRequests'
+	dst, err = e.ExecutionRequests.MarshalSSZTo(dst)
+	return
+}
+
+func (e *electraExecutionPayloadAndBlobsBundle) SizeSSZ() (size int) {
+	size = 12
+	if e.ExecutionPayload != nil {
+		size += e.ExecutionPayload.SizeSSZ()
+	}
+	if e.BlobsBundle == nil {
+		size += new(denebApi.BlobsBundle).SizeSSZ()
+	} else {
+		size += e.BlobsBundle.SizeSSZ()
+	}
+	if e.ExecutionRequests == nil {
+		return size + new(electra.ExecutionRequests).SizeSSZ()
+	}
+	return size + e.ExecutionRequests.SizeSSZ()
+}
+
+func (e *electraExecutionPayloadAndBlobsBundle) UnmarshalSSZ(buf []byte) error {
+	offsets, err := readOffsets(buf, 3)
+	if err != nil {
+		return err
+	}
+
+	res := electraExecutionPayloadAndBlobsBundle{
+		ExecutionPayload:  new(deneb.ExecutionPayload),
+		BlobsBundle:       new(denebApi.BlobsBundle),
+		ExecutionRequests: new(electra.ExecutionRequests),
+	}
+	if err := res.ExecutionPayload.UnmarshalSSZ(buf[offsets[0]:offsets[1]]); err != nil {
+		return err
+	}
+	if err := res.BlobsBundle.UnmarshalSSZ(buf[offsets[1]:offsets[2]]); err != nil {
+		return err
+	}
+	if err := res.ExecutionRequests.UnmarshalSSZ(buf[offsets[2]:]); err != nil {
+		return err
+	}
+	*e = res
+	return nil
+}
+
+func (e *electraExecutionPayloadAndBlobsBundle) MarshalYAML() ([]byte, error) {
+	data, err := e.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return yaml.JSONToYAML(data)
+}
+
+func (e *electraExecutionPayloadAndBlobsBundle) UnmarshalYAML(input []byte) error {
+	data, err := yaml.YAMLToJSON(input)
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalJSON(data)
+}
+
+// readOffsets reads the offsets of a container made of count variable size fields, checking that
+// the first field starts right after the offsets and that the offsets are in order
+func readOffsets(buf []byte, count int) ([]uint64, error) {
+	size := uint64(len(buf))
+	fixedSize := uint64(4 * count)
+	if size < fixedSize {
+		return nil, ssz.ErrSize
+	}
+
+	offsets := make([]uint64, count)
+	for i := range offsets {
+		offsets[i] = ssz.ReadOffset(buf[4*i : 4*i+4])
+		if offsets[i] > size {
+			return nil, ssz.ErrOffset
+		}
+		if i == 0 && offsets[i] != fixedSize {
+			return nil, fmt.Errorf("%w: first offset %d", ssz.ErrInvalidVariableOffset, offsets[i])
+		}
+		if i > 0 && offsets[i] < offsets[i-1] {
+			return nil, ssz.ErrOffset
+		}
+	}
+	return offsets, nil
+}
@@ -1,7 +1,11 @@
 package common
 
 import (
+	"errors"
+	"fmt"
+	"math/big"
 
+	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	capella "github.com/attestantio/go-eth2-client/spec/capella"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -29,4 +33,51 @@ func ComputeTransactionsRoot(t []bellatrix.Transaction) (phase0.Root, error) {
 
 	transactions := utilbellatrix.ExecutionPayloadTransactions{Transactions: t}
 	return transactions.HashTreeRoot()
-}
\ No newline at end of file
+}
+
+// DataVersionFromName converts a fork version name such as "deneb", as returned by Version(), to its spec.DataVersion
+func DataVersionFromName(name string) (spec.DataVersion, error) {
+	var version spec.DataVersion
+	if err := version.UnmarshalJSON([]byte(fmt.Sprintf("%q", name))); err != nil {
+		return spec.DataVersionUnknown, err
+	}
+	return version, nil
+}
+
+// BigIntToUint256SSZ encodes the value as the 32 byte little endian uint256 used by ssz, where a nil value encodes as zero
+func BigIntToUint256SSZ(value *big.Int) ([32]byte, error) {
+	var res [32]byte
+	if value == nil {
+		return res, nil
+	}
+	if value.Sign() < 0 || value.BitLen() > 256 {
+		return res, errors.New("value does not fit in a uint256")
+	}
+	value.FillBytes(res[:])
+	for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+		res[i], res[j] = res[j], res[i]
+	}
+	return res, nil
+}
+
+// Uint256SSZToBigInt decodes a little endian ssz uint256 without modifying the input
+func Uint256SSZToBigInt(buf []byte) *big.Int {
+	value := make([]byte, len(buf))
+	for i := range buf {
+		value[len(buf)-1-i] = buf[i]
+	}
+	return new(big.Int).SetBytes(value)
+}
+
+// BigIntToLegacySSZBytes returns the minimal little endian bytes of the value, which earlier versions hashed in
+// place of a uint256. The resulting root differs from the uint256 root for a zero value
+func BigIntToLegacySSZBytes(value *big.Int) []byte {
+	if value == nil {
+		return []byte{}
+	}
+	valueBytes := value.Bytes() // Big endian
+	for i, j := 0, len(valueBytes)-1; i < j; i, j = i+1, j-1 {
+		valueBytes[i], valueBytes[j] = valueBytes[j], valueBytes[i]
+	} // Little endian
+	return valueBytes
+}
@@ -0,0 +1,139 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	uint256 "github.com/holiman/uint256"
+)
+
+// FieldMismatch describes a single field that differs between two representations of the same block
+type FieldMismatch struct {
+	Field    string `json:"field"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+func (m FieldMismatch) String() string {
+	return fmt.Sprintf("%s (expected %s, got %s)", m.Field, m.Expected, m.Actual)
+}
+
+// MismatchError is returned when two representations of the same block disagree,
+// listing every field that differs rather than only the first
+type MismatchError struct {
+	Mismatches []FieldMismatch `json:"mismatches"`
+}
+
+func (e *MismatchError) Error() string {
+	fields := make([]string, len(e.Mismatches))
+	for i, m := range e.Mismatches {
+		fields[i] = m.String()
+	}
+	return "mismatched fields: " + strings.Join(fields, ", ")
+}
+
+// VerifyPayloadMatchesHeader compares every field of the execution payload header with the execution payload,
+// recomputing the transactions and withdrawals roots from the payload. A *MismatchError listing all mismatching
+// fields is returned if the payload does not match the header
+func VerifyPayloadMatchesHeader(payload *VersionedExecutionPayload, header *VersionedExecutionPayloadHeader) error {
+	if payload == nil {
+		return errors.New("no ExecutionPayload set")
+	}
+	if header == nil {
+		return errors.New("no ExecutionPayloadHeader set")
+	}
+
+	payloadVersion, err := payload.VersionNumber()
+	if err != nil {
+		return err
+	}
+	headerVersion, err := header.VersionNumber()
+	if err != nil {
+		return err
+	}
+	if payloadVersion != headerVersion {
+		return &MismatchError{Mismatches: []FieldMismatch{{
+			Field:    "version",
+			Expected: spec.DataVersion(headerVersion).String(),
+			Actual:   spec.DataVersion(payloadVersion).String(),
+		}}}
+	}
+
+	basePayload, err := payload.ToBaseExecutionPayload()
+	if err != nil {
+		return err
+	}
+	baseHeader, err := header.ToBaseExecutionPayloadHeader()
+	if err != nil {
+		return err
+	}
+
+	mismatches, err := compareExecutionPayloadHeader(spec.DataVersion(headerVersion), baseHeader, basePayload)
+	if err != nil {
+		return err
+	}
+	if len(mismatches) > 0 {
+		return &MismatchError{Mismatches: mismatches}
+	}
+	return nil
+}
+
+// compareExecutionPayloadHeader compares every field of the header that exists in the given
+// fork version with the payload, recomputing the transactions and withdrawals roots from the payload
+func compareExecutionPayloadHeader(
+	version spec.DataVersion,
+	header BaseExecutionPayloadHeader,
+	payload BaseExecutionPayload,
+) ([]FieldMismatch, error) {
+	mismatches := []FieldMismatch{}
+
+	compare := func(field string, expected, actual string) {
+		if expected != actual {
+			mismatches = append(mismatches, FieldMismatch{Field: field, Expected: expected, Actual: actual})
+		}
+	}
+
+	compare("parent_hash", fmt.Sprintf("%#x", header.ParentHash), fmt.Sprintf("%#x", payload.ParentHash))
+	compare("fee_recipient", fmt.Sprintf("%#x", header.FeeRecipient), fmt.Sprintf("%#x", payload.FeeRecipient))
+	compare("state_root", fmt.Sprintf("%#x", header.StateRoot), fmt.Sprintf("%#x", payload.StateRoot))
+	compare("receipts_root", fmt.Sprintf("%#x", header.ReceiptsRoot), fmt.Sprintf("%#x", payload.ReceiptsRoot))
+	compare("logs_bloom", fmt.Sprintf("%#x", header.LogsBloom), fmt.Sprintf("%#x", payload.LogsBloom))
+	compare("prev_randao", fmt.Sprintf("%#x", header.PrevRandao), fmt.Sprintf("%#x", payload.PrevRandao))
+	compare("block_number", fmt.Sprint(header.BlockNumber), fmt.Sprint(payload.BlockNumber))
+	compare("gas_limit", fmt.Sprint(header.GasLimit), fmt.Sprint(payload.GasLimit))
+	compare("gas_used", fmt.Sprint(header.GasUsed), fmt.Sprint(payload.GasUsed))
+	compare("timestamp", fmt.Sprint(header.Timestamp), fmt.Sprint(payload.Timestamp))
+	compare("extra_data", fmt.Sprintf("%#x", header.ExtraData), fmt.Sprintf("%#x", payload.ExtraData))
+	compare("base_fee_per_gas", formatUint256(header.BaseFeePerGas), formatUint256(payload.BaseFeePerGas))
+	compare("block_hash", fmt.Sprintf("%#x", header.BlockHash), fmt.Sprintf("%#x", payload.BlockHash))
+
+	transactionsRoot, err := ComputeTransactionsRoot(payload.Transactions)
+	if err != nil {
+		return nil, err
+	}
+	compare("transactions_root", fmt.Sprintf("%#x", header.TransactionsRoot), fmt.Sprintf("%#x", transactionsRoot))
+
+	if version >= spec.DataVersionCapella {
+		withdrawalsRoot, err := ComputeWithdrawalsRoot(payload.Withdrawals)
+		if err != nil {
+			return nil, err
+		}
+		compare("withdrawals_root", fmt.Sprintf("%#x", header.WithdrawalsRoot), fmt.Sprintf("%#x", withdrawalsRoot))
+	}
+
+	if version >= spec.DataVersionDeneb {
+		compare("blob_gas_used", fmt.Sprint(header.BlobGasUsed), fmt.Sprint(payload.BlobGasUsed))
+		compare("excess_blob_gas", fmt.Sprint(header.ExcessBlobGas), fmt.Sprint(payload.ExcessBlobGas))
+	}
+
+	return mismatches, nil
+}
+
+func formatUint256(v *uint256.Int) string {
+	if v == nil {
+		return "nil"
+	}
+	return v.Dec()
+}
@@ -0,0 +1,80 @@
+package common
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	bellatrix "github.com/attestantio/go-eth2-client/spec/bellatrix"
+)
+
+func TestVerifyPayloadMatchesHeader(t *testing.T) {
+	for _, version := range []spec.DataVersion{
+		spec.DataVersionBellatrix,
+		spec.DataVersionCapella,
+		spec.DataVersionDeneb,
+		spec.DataVersionElectra,
+	} {
+		payload, err := ConstructExecutionPayload(version.String(), testBaseExecutionPayload())
+		if err != nil {
+			t.Fatal(err)
+		}
+		header, err := payload.ToVersionedExecutionPayloadHeader()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := VerifyPayloadMatchesHeader(&payload, &header); err != nil {
+			t.Fatalf("%s: %v", version, err)
+		}
+	}
+}
+
+func TestVerifyPayloadMatchesHeaderMismatches(t *testing.T) {
+	payload, err := ConstructExecutionPayload(spec.DataVersionDeneb.String(), testBaseExecutionPayload())
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, err := payload.ToVersionedExecutionPayloadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload.Deneb.GasUsed++
+	payload.Deneb.Transactions = []bellatrix.Transaction{{0x01}}
+
+	err = VerifyPayloadMatchesHeader(&payload, &header)
+	var mismatchErr *MismatchError
+	if !errors.As(err, &mismatchErr) {
+		t.Fatalf("expected a mismatch error, got %v", err)
+	}
+	fields := map[string]bool{}
+	for _, mismatch := range mismatchErr.Mismatches {
+		fields[mismatch.Field] = true
+	}
+	if len(fields) != 2 || !fields["gas_used"] || !fields["transactions_root"] {
+		t.Fatalf("unexpected mismatches %v", mismatchErr.Mismatches)
+	}
+}
+
+func TestVerifyPayloadMatchesHeaderVersion(t *testing.T) {
+	payload, err := ConstructExecutionPayload(spec.DataVersionCapella.String(), testBaseExecutionPayload())
+	if err != nil {
+		t.Fatal(err)
+	}
+	denebPayload, err := ConstructExecutionPayload(spec.DataVersionDeneb.String(), testBaseExecutionPayload())
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, err := denebPayload.ToVersionedExecutionPayloadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = VerifyPayloadMatchesHeader(&payload, &header)
+	var mismatchErr *MismatchError
+	if !errors.As(err, &mismatchErr) || mismatchErr.Mismatches[0].Field != "version" {
+		t.Fatalf("expected a version mismatch, got %v", err)
+	}
+	if err := VerifyPayloadMatchesHeader(nil, &header); err == nil {
+		t.Fatal("expected an error for a missing payload")
+	}
+}
@@ -0,0 +1,120 @@
+package common
+
+import (
+	"errors"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+var (
+	DomainTypeBeaconProposer = phase0.DomainType{0x00, 0x00, 0x00, 0x00}
+	DomainTypeAppBuilder     = phase0.DomainType{0x00, 0x00, 0x00, 0x01}
+
+	ErrInvalidPubkey    = errors.New("invalid public key")
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrInvalidSecretKey = errors.New("invalid secret key")
+)
+
+// blsDST is the domain separation tag of the proof of possession scheme used by the beacon chain
+var blsDST = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+
+type BLSSecretKey = blst.SecretKey
+
+// ComputeDomain computes the signing domain of the domain type for the fork version and genesis validators root
+func ComputeDomain(domainType phase0.DomainType, forkVersion phase0.Version, genesisValidatorsRoot phase0.Root) (phase0.Domain, error) {
+	forkData := phase0.ForkData{
+		CurrentVersion:        forkVersion,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	}
+	forkDataRoot, err := forkData.HashTreeRoot()
+	if err != nil {
+		return phase0.Domain{}, err
+	}
+
+	var domain phase0.Domain
+	copy(domain[0:4], domainType[:])
+	copy(domain[4:], forkDataRoot[0:28])
+	return domain, nil
+}
+
+// ComputeBuilderDomain computes the DOMAIN_APPLICATION_BUILDER domain used for builder registrations and bids,
+// which only depends on the genesis fork version of the network
+func ComputeBuilderDomain(genesisForkVersion phase0.Version) (phase0.Domain, error) {
+	return ComputeDomain(DomainTypeAppBuilder, genesisForkVersion, phase0.Root{})
+}
+
+// ComputeBeaconProposerDomain computes the DOMAIN_BEACON_PROPOSER domain used for beacon blocks,
+// where the fork version is the one of the epoch the block is proposed in
+func ComputeBeaconProposerDomain(forkVersion phase0.Version, genesisValidatorsRoot phase0.Root) (phase0.Domain, error) {
+	return ComputeDomain(DomainTypeBeaconProposer, forkVersion, genesisValidatorsRoot)
+}
+
+// SigningRoot computes the root signed for the object in the domain
+func SigningRoot(obj ssz.HashRoot, domain phase0.Domain) (phase0.Root, error) {
+	objectRoot, err := obj.HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, err
+	}
+
+	signingData := phase0.SigningData{
+		ObjectRoot: objectRoot,
+		Domain:     domain,
+	}
+	return signingData.HashTreeRoot()
+}
+
+// BLSSecretKeyFromBytes deserializes a 32 byte BLS secret key
+func BLSSecretKeyFromBytes(b []byte) (*BLSSecretKey, error) {
+	secretKey := new(BLSSecretKey).Deserialize(b)
+	if secretKey == nil {
+		return nil, ErrInvalidSecretKey
+	}
+	return secretKey, nil
+}
+
+// BLSPublicKeyFromSecretKey returns the compressed public key of the secret key
+func BLSPublicKeyFromSecretKey(secretKey *BLSSecretKey) phase0.BLSPubKey {
+	var pubkey phase0.BLSPubKey
+	copy(pubkey[:], new(blst.P1Affine).From(secretKey).Compress())
+	return pubkey
+}
+
+// SignRoot signs the signing root with the secret key
+func SignRoot(root phase0.Root, secretKey *BLSSecretKey) phase0.BLSSignature {
+	var signature phase0.BLSSignature
+	copy(signature[:], new(blst.P2Affine).Sign(secretKey, root[:], blsDST).Compress())
+	return signature
+}
+
+// VerifyRoot verifies the signature of the public key over the signing root
+func VerifyRoot(root phase0.Root, pubkey phase0.BLSPubKey, signature phase0.BLSSignature) (bool, error) {
+	publicKey := new(blst.P1Affine).Uncompress(pubkey[:])
+	if publicKey == nil || !publicKey.KeyValidate() {
+		return false, ErrInvalidPubkey
+	}
+	sig := new(blst.P2Affine).Uncompress(signature[:])
+	if sig == nil {
+		return false, ErrInvalidSignature
+	}
+	return sig.Verify(true, publicKey, false, root[:], blsDST), nil
+}
+
+// SignObject computes the signing root of the object in the domain and signs it with the secret key
+func SignObject(obj ssz.HashRoot, domain phase0.Domain, secretKey *BLSSecretKey) (phase0.BLSSignature, error) {
+	root, err := SigningRoot(obj, domain)
+	if err != nil {
+		return phase0.BLSSignature{}, err
+	}
+	return SignRoot(root, secretKey), nil
+}
+
+// VerifyObject computes the signing root of the object in the domain and verifies the signature of the public key over it
+func VerifyObject(obj ssz.HashRoot, domain phase0.Domain, pubkey phase0.BLSPubKey, signature phase0.BLSSignature) (bool, error) {
+	root, err := SigningRoot(obj, domain)
+	if err != nil {
+		return false, err
+	}
+	return VerifyRoot(root, pubkey, signature)
+}
@@ -0,0 +1,87 @@
+package common
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+func testBLSSecretKey(t *testing.T, seed byte) *BLSSecretKey {
+	t.Helper()
+	b := make([]byte, 32)
+	b[31] = seed
+	secretKey, err := BLSSecretKeyFromBytes(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return secretKey
+}
+
+func TestComputeBuilderDomain(t *testing.T) {
+	// The builder domain of mainnet, whose genesis fork version is zero
+	expected := "0x00000001f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a9"
+	domain, err := ComputeBuilderDomain(phase0.Version{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual := phase0.Root(domain).String(); actual != expected {
+		t.Fatalf("expected %s, got %s", expected, actual)
+	}
+}
+
+func TestBLSPublicKeyFromSecretKey(t *testing.T) {
+	// The public key of the secret key one is the generator of G1
+	expected := "0x97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb"
+	if pubkey := BLSPublicKeyFromSecretKey(testBLSSecretKey(t, 1)); pubkey.String() != expected {
+		t.Fatalf("expected %s, got %s", expected, pubkey)
+	}
+}
+
+func TestSignAndVerifyObject(t *testing.T) {
+	secretKey := testBLSSecretKey(t, 1)
+	pubkey := BLSPublicKeyFromSecretKey(secretKey)
+	domain, err := ComputeBeaconProposerDomain(phase0.Version{0x05}, phase0.Root{0x01})
+	if err != nil {
+		t.Fatal(err)
+	}
+	obj := &phase0.Checkpoint{Epoch: 1, Root: phase0.Root{0x02}}
+
+	signature, err := SignObject(obj, domain, secretKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := VerifyObject(obj, domain, pubkey, signature); err != nil || !ok {
+		t.Fatalf("signature not verified: %v", err)
+	}
+
+	// The signature does not verify for another object, domain or key
+	if ok, _ := VerifyObject(&phase0.Checkpoint{Epoch: 2}, domain, pubkey, signature); ok {
+		t.Fatal("signature verified for another object")
+	}
+	otherDomain, err := ComputeBuilderDomain(phase0.Version{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := VerifyObject(obj, otherDomain, pubkey, signature); ok {
+		t.Fatal("signature verified in another domain")
+	}
+	if ok, _ := VerifyObject(obj, domain, BLSPublicKeyFromSecretKey(testBLSSecretKey(t, 2)), signature); ok {
+		t.Fatal("signature verified for another key")
+	}
+}
+
+func TestVerifyRootErrors(t *testing.T) {
+	secretKey := testBLSSecretKey(t, 1)
+	signature := SignRoot(phase0.Root{0x01}, secretKey)
+
+	if _, err := VerifyRoot(phase0.Root{0x01}, phase0.BLSPubKey{0x01}, signature); !errors.Is(err, ErrInvalidPubkey) {
+		t.Fatalf("expected an invalid public key error, got %v", err)
+	}
+	if _, err := VerifyRoot(phase0.Root{0x01}, BLSPublicKeyFromSecretKey(secretKey), phase0.BLSSignature{0x01}); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected an invalid signature error, got %v", err)
+	}
+	if _, err := BLSSecretKeyFromBytes([]byte{0x01}); !errors.Is(err, ErrInvalidSecretKey) {
+		t.Fatalf("expected an invalid secret key error, got %v", err)
+	}
+}
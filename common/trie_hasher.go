@@ -0,0 +1,124 @@
+package common
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// listHasher computes the Merkle Patricia trie root of a list as types.DeriveSha feeds it, keeping the
+// entries in memory and building the trie nodes on Hash. The lists of a payload are small enough
+// that this avoids depending on the go-ethereum trie package and its database backends
+type listHasher struct {
+	entries []listEntry
+}
+
+type listEntry struct {
+	path  []byte
+	value []byte
+}
+
+var _ types.TrieHasher = (*listHasher)(nil)
+
+func (h *listHasher) Reset() {
+	h.entries = h.entries[:0]
+}
+
+func (h *listHasher) Update(key, value []byte) error {
+	h.entries = append(h.entries, listEntry{path: keyToNibbles(key), value: common.CopyBytes(value)})
+	return nil
+}
+
+func (h *listHasher) Hash() common.Hash {
+	if len(h.entries) == 0 {
+		return types.EmptyRootHash
+	}
+	sort.Slice(h.entries, func(i, j int) bool {
+		return bytes.Compare(h.entries[i].path, h.entries[j].path) < 0
+	})
+	return crypto.Keccak256Hash(encodeTrieNode(h.entries, 0))
+}
+
+// encodeTrieNode returns the RLP encoding of the node holding the sorted entries below the given depth
+func encodeTrieNode(entries []listEntry, depth int) []byte {
+	if len(entries) == 1 {
+		return mustEncodeRLP([]interface{}{hexPrefix(entries[0].path[depth:], true), entries[0].value})
+	}
+
+	first, last := entries[0].path[depth:], entries[len(entries)-1].path[depth:]
+	prefix := 0
+	for prefix < len(first) && prefix < len(last) && first[prefix] == last[prefix] {
+		prefix++
+	}
+	if prefix > 0 {
+		return mustEncodeRLP([]interface{}{hexPrefix(first[:prefix], false), trieNodeRef(encodeTrieNode(entries, depth+prefix))})
+	}
+
+	branch := make([]interface{}, 17)
+	branch[16] = []byte{}
+	if len(entries[0].path) == depth {
+		branch[16] = entries[0].value
+		entries = entries[1:]
+	}
+	for nibble := byte(0); nibble < 16; nibble++ {
+		end := 0
+		for end < len(entries) && entries[end].path[depth] == nibble {
+			end++
+		}
+		if end == 0 {
+			branch[nibble] = []byte{}
+			continue
+		}
+		branch[nibble] = trieNodeRef(encodeTrieNode(entries[:end], depth+1))
+		entries = entries[end:]
+	}
+	return mustEncodeRLP(branch)
+}
+
+// trieNodeRef embeds nodes shorter than a hash in their parent and references the others by hash
+func trieNodeRef(encoded []byte) interface{} {
+	if len(encoded) < common.HashLength {
+		return rlp.RawValue(encoded)
+	}
+	return crypto.Keccak256(encoded)
+}
+
+// hexPrefix is the compact encoding of a path of nibbles, flagging its parity and whether it ends in a leaf
+func hexPrefix(nibbles []byte, leaf bool) []byte {
+	flag := byte(0)
+	if leaf {
+		flag = 2
+	}
+	encoded := make([]byte, len(nibbles)/2+1)
+	if len(nibbles)%2 == 1 {
+		encoded[0] = (flag+1)<<4 | nibbles[0]
+		nibbles = nibbles[1:]
+	} else {
+		encoded[0] = flag << 4
+	}
+	for i := 0; i < len(nibbles); i += 2 {
+		encoded[i/2+1] = nibbles[i]<<4 | nibbles[i+1]
+	}
+	return encoded
+}
+
+func keyToNibbles(key []byte) []byte {
+	nibbles := make([]byte, 0, len(key)*2)
+	for _, b := range key {
+		nibbles = append(nibbles, b>>4, b&0x0f)
+	}
+	return nibbles
+}
+
+func mustEncodeRLP(val interface{}) []byte {
+	encoded, err := rlp.EncodeToBytes(val)
+	if err != nil {
+		// Trie nodes only hold byte strings and lists of them, which always encode
+		panic(err)
+	}
+	return encoded
+}
@@ -10,6 +10,7 @@ import (
 	bellatrix "github.com/attestantio/go-eth2-client/spec/bellatrix"
 	capella "github.com/attestantio/go-eth2-client/spec/capella"
 	deneb "github.com/attestantio/go-eth2-client/spec/deneb"
+	electra "github.com/attestantio/go-eth2-client/spec/electra"
 	phase0 "github.com/attestantio/go-eth2-client/spec/phase0"
 
 	"github.com/attestantio/go-eth2-client/spec/altair"
@@ -27,18 +28,21 @@ type BaseBeaconBlock struct {
 }
 
 type BaseBeaconBlockBody struct {
-	RANDAOReveal          phase0.BLSSignature `ssz-size:"96"`
-	ETH1Data              *phase0.ETH1Data
-	Graffiti              [32]byte                      `ssz-size:"32"`
-	ProposerSlashings     []*phase0.ProposerSlashing    `ssz-max:"16"`
-	AttesterSlashings     []*phase0.AttesterSlashing    `ssz-max:"2"`
-	Attestations          []*phase0.Attestation         `ssz-max:"128"`
-	Deposits              []*phase0.Deposit             `ssz-max:"16"`
-	VoluntaryExits        []*phase0.SignedVoluntaryExit `ssz-max:"16"`
-	SyncAggregate         *altair.SyncAggregate
-	ExecutionPayload      *BaseExecutionPayload
-	BLSToExecutionChanges []*capella.SignedBLSToExecutionChange `ssz-max:"16"`
-	BlobKZGCommitments    []deneb.KZGCommitment                 `ssz-max:"4096" ssz-size:"?,48"`
+	RANDAOReveal             phase0.BLSSignature `ssz-size:"96"`
+	ETH1Data                 *phase0.ETH1Data
+	Graffiti                 [32]byte                      `ssz-size:"32"`
+	ProposerSlashings        []*phase0.ProposerSlashing    `ssz-max:"16"`
+	AttesterSlashings        []*phase0.AttesterSlashing    `ssz-max:"2"`
+	Attestations             []*phase0.Attestation         `ssz-max:"128"`
+	Deposits                 []*phase0.Deposit             `ssz-max:"16"`
+	VoluntaryExits           []*phase0.SignedVoluntaryExit `ssz-max:"16"`
+	SyncAggregate            *altair.SyncAggregate
+	ExecutionPayload         *BaseExecutionPayload
+	BLSToExecutionChanges    []*capella.SignedBLSToExecutionChange `ssz-max:"16"`
+	BlobKZGCommitments       []deneb.KZGCommitment                 `ssz-max:"4096" ssz-size:"?,48"`
+	AttesterSlashingsElectra []*electra.AttesterSlashing           `ssz-max:"1"`
+	AttestationsElectra      []*electra.Attestation                `ssz-max:"8"`
+	ExecutionRequests        *electra.ExecutionRequests
 }
 
 func ConstructBeaconBlock(
@@ -114,6 +118,28 @@ func ConstructBeaconBlock(
 				BlobKZGCommitments:    beaconBlock.Body.BlobKZGCommitments,
 			},
 		}
+	case spec.DataVersionElectra.String():
+		res.Electra = &electra.BeaconBlock{
+			Slot:          beaconBlock.Slot,
+			ProposerIndex: beaconBlock.ProposerIndex,
+			ParentRoot:    beaconBlock.ParentRoot,
+			StateRoot:     beaconBlock.StateRoot,
+			Body: &electra.BeaconBlockBody{
+				RANDAOReveal:          beaconBlock.Body.RANDAOReveal,
+				ETH1Data:              beaconBlock.Body.ETH1Data,
+				Graffiti:              beaconBlock.Body.Graffiti,
+				ProposerSlashings:     beaconBlock.Body.ProposerSlashings,
+				AttesterSlashings:     beaconBlock.Body.AttesterSlashingsElectra,
+				Attestations:          beaconBlock.Body.AttestationsElectra,
+				Deposits:              beaconBlock.Body.Deposits,
+				VoluntaryExits:        beaconBlock.Body.VoluntaryExits,
+				SyncAggregate:         beaconBlock.Body.SyncAggregate,
+				ExecutionPayload:      versionedExecutionPayload.Electra,
+				BLSToExecutionChanges: beaconBlock.Body.BLSToExecutionChanges,
+				BlobKZGCommitments:    beaconBlock.Body.BlobKZGCommitments,
+				ExecutionRequests:     beaconBlock.Body.ExecutionRequests,
+			},
+		}
 	default:
 		return res, errors.New("unsupported fork version")
 	}
@@ -258,6 +284,49 @@ func (b *VersionedBeaconBlock) ToBaseBeaconBlock() (BaseBeaconBlock, error) {
 			BLSToExecutionChanges: b.Deneb.Body.BLSToExecutionChanges,
 			BlobKZGCommitments:    b.Deneb.Body.BlobKZGCommitments,
 		}
+	case b.Electra != nil:
+
+		res.ParentRoot = b.Electra.ParentRoot
+		res.ProposerIndex = b.Electra.ProposerIndex
+		res.Slot = b.Electra.Slot
+		res.StateRoot = b.Electra.StateRoot
+		res.Body = &BaseBeaconBlockBody{
+			RANDAOReveal:             b.Electra.Body.RANDAOReveal,
+			ETH1Data:                 b.Electra.Body.ETH1Data,
+			Graffiti:                 b.Electra.Body.Graffiti,
+			ProposerSlashings:        b.Electra.Body.ProposerSlashings,
+			AttesterSlashingsElectra: b.Electra.Body.AttesterSlashings,
+			AttestationsElectra:      b.Electra.Body.Attestations,
+			Deposits:                 b.Electra.Body.Deposits,
+			VoluntaryExits:           b.Electra.Body.VoluntaryExits,
+			SyncAggregate:            b.Electra.Body.SyncAggregate,
+			ExecutionPayload: &BaseExecutionPayload{
+				ParentHash:        b.Electra.Body.ExecutionPayload.ParentHash,
+				FeeRecipient:      b.Electra.Body.ExecutionPayload.FeeRecipient,
+				StateRoot:         b.Electra.Body.ExecutionPayload.StateRoot,
+				ReceiptsRoot:      b.Electra.Body.ExecutionPayload.ReceiptsRoot,
+				LogsBloom:         b.Electra.Body.ExecutionPayload.LogsBloom,
+				PrevRandao:        b.Electra.Body.ExecutionPayload.PrevRandao,
+				BlockNumber:       b.Electra.Body.ExecutionPayload.BlockNumber,
+				GasLimit:          b.Electra.Body.ExecutionPayload.GasLimit,
+				GasUsed:           b.Electra.Body.ExecutionPayload.GasUsed,
+				Timestamp:         b.Electra.Body.ExecutionPayload.Timestamp,
+				ExtraData:         b.Electra.Body.ExecutionPayload.ExtraData,
+				BaseFeePerGas:     b.Electra.Body.ExecutionPayload.BaseFeePerGas,
+				BlockHash:         b.Electra.Body.ExecutionPayload.BlockHash,
+				Transactions:      b.Electra.Body.ExecutionPayload.Transactions,
+				Withdrawals:       b.Electra.Body.ExecutionPayload.Withdrawals,
+				BlobGasUsed:       b.Electra.Body.ExecutionPayload.BlobGasUsed,
+				ExcessBlobGas:     b.Electra.Body.ExecutionPayload.ExcessBlobGas,
+				ExecutionRequests: b.Electra.Body.ExecutionRequests,
+				BlobsBundle: &denebApi.BlobsBundle{
+					Commitments: b.Electra.Body.BlobKZGCommitments,
+				},
+			},
+			BLSToExecutionChanges: b.Electra.Body.BLSToExecutionChanges,
+			BlobKZGCommitments:    b.Electra.Body.BlobKZGCommitments,
+			ExecutionRequests:     b.Electra.Body.ExecutionRequests,
+		}
 	default:
 		return res, errors.New("unsupported fork version")
 	}
@@ -290,15 +359,17 @@ func (b *VersionedBeaconBlock) ToVersionedBlindedBeaconBlock() (VersionedBlinded
 		ParentRoot:    baseBeaconBlock.ParentRoot,
 		StateRoot:     baseBeaconBlock.StateRoot,
 		Body: &BaseBlindedBeaconBlockBody{
-			RANDAOReveal:      baseBeaconBlock.Body.RANDAOReveal,
-			ETH1Data:          baseBeaconBlock.Body.ETH1Data,
-			Graffiti:          baseBeaconBlock.Body.Graffiti,
-			ProposerSlashings: baseBeaconBlock.Body.ProposerSlashings,
-			AttesterSlashings: baseBeaconBlock.Body.AttesterSlashings,
-			Attestations:      baseBeaconBlock.Body.Attestations,
-			Deposits:          baseBeaconBlock.Body.Deposits,
-			VoluntaryExits:    baseBeaconBlock.Body.VoluntaryExits,
-			SyncAggregate:     baseBeaconBlock.Body.SyncAggregate,
+			RANDAOReveal:             baseBeaconBlock.Body.RANDAOReveal,
+			ETH1Data:                 baseBeaconBlock.Body.ETH1Data,
+			Graffiti:                 baseBeaconBlock.Body.Graffiti,
+			ProposerSlashings:        baseBeaconBlock.Body.ProposerSlashings,
+			AttesterSlashings:        baseBeaconBlock.Body.AttesterSlashings,
+			Attestations:             baseBeaconBlock.Body.Attestations,
+			AttesterSlashingsElectra: baseBeaconBlock.Body.AttesterSlashingsElectra,
+			AttestationsElectra:      baseBeaconBlock.Body.AttestationsElectra,
+			Deposits:                 baseBeaconBlock.Body.Deposits,
+			VoluntaryExits:           baseBeaconBlock.Body.VoluntaryExits,
+			SyncAggregate:            baseBeaconBlock.Body.SyncAggregate,
 			ExecutionPayloadHeader: &BaseExecutionPayloadHeader{
 				ParentHash:       baseBeaconBlock.Body.ExecutionPayload.ParentHash,
 				FeeRecipient:     baseBeaconBlock.Body.ExecutionPayload.FeeRecipient,
@@ -320,6 +391,7 @@ func (b *VersionedBeaconBlock) ToVersionedBlindedBeaconBlock() (VersionedBlinded
 			},
 			BLSToExecutionChanges: baseBeaconBlock.Body.BLSToExecutionChanges,
 			BlobKZGCommitments:    baseBeaconBlock.Body.BlobKZGCommitments,
+			ExecutionRequests:     baseBeaconBlock.Body.ExecutionRequests,
 		},
 	}
 
@@ -331,6 +403,8 @@ func (b *VersionedBeaconBlock) ToVersionedBlindedBeaconBlock() (VersionedBlinded
 		forkVersion = spec.DataVersionCapella.String()
 	case b.Deneb != nil:
 		forkVersion = spec.DataVersionDeneb.String()
+	case b.Electra != nil:
+		forkVersion = spec.DataVersionElectra.String()
 	default:
 		return res, errors.New("unsupported fork version")
 	}
@@ -351,6 +425,8 @@ func (b *VersionedBeaconBlock) Version() (string, error) {
 		return spec.DataVersionCapella.String(), nil
 	case b.Deneb != nil:
 		return spec.DataVersionDeneb.String(), nil
+	case b.Electra != nil:
+		return spec.DataVersionElectra.String(), nil
 	default:
 		return "", errors.New("no fork version set")
 	}
@@ -364,6 +440,8 @@ func (b *VersionedBeaconBlock) VersionNumber() (uint64, error) {
 		return uint64(spec.DataVersionCapella), nil
 	case b.Deneb != nil:
 		return uint64(spec.DataVersionDeneb), nil
+	case b.Electra != nil:
+		return uint64(spec.DataVersionElectra), nil
 	default:
 		return 0, errors.New("no fork version set")
 	}
@@ -9,9 +9,11 @@ import (
 	bellatrixApi "github.com/attestantio/go-eth2-client/api/v1/bellatrix"
 	capellaApi "github.com/attestantio/go-eth2-client/api/v1/capella"
 	denebApi "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	electraApi "github.com/attestantio/go-eth2-client/api/v1/electra"
 
 	capella "github.com/attestantio/go-eth2-client/spec/capella"
 	deneb "github.com/attestantio/go-eth2-client/spec/deneb"
+	electra "github.com/attestantio/go-eth2-client/spec/electra"
 
 	phase0 "github.com/attestantio/go-eth2-client/spec/phase0"
 
@@ -30,18 +32,21 @@ type BaseBlindedBeaconBlock struct {
 }
 
 type BaseBlindedBeaconBlockBody struct {
-	RANDAOReveal           phase0.BLSSignature `ssz-size:"96"`
-	ETH1Data               *phase0.ETH1Data
-	Graffiti               [32]byte                      `ssz-size:"32"`
-	ProposerSlashings      []*phase0.ProposerSlashing    `ssz-max:"16"`
-	AttesterSlashings      []*phase0.AttesterSlashing    `ssz-max:"2"`
-	Attestations           []*phase0.Attestation         `ssz-max:"128"`
-	Deposits               []*phase0.Deposit             `ssz-max:"16"`
-	VoluntaryExits         []*phase0.SignedVoluntaryExit `ssz-max:"16"`
-	SyncAggregate          *altair.SyncAggregate
-	ExecutionPayloadHeader *BaseExecutionPayloadHeader
-	BLSToExecutionChanges  []*capella.SignedBLSToExecutionChange `ssz-max:"16"`
-	BlobKZGCommitments     []deneb.KZGCommitment                 `ssz-max:"4096" ssz-size:"?,48"`
+	RANDAOReveal             phase0.BLSSignature `ssz-size:"96"`
+	ETH1Data                 *phase0.ETH1Data
+	Graffiti                 [32]byte                      `ssz-size:"32"`
+	ProposerSlashings        []*phase0.ProposerSlashing    `ssz-max:"16"`
+	AttesterSlashings        []*phase0.AttesterSlashing    `ssz-max:"2"`
+	Attestations             []*phase0.Attestation         `ssz-max:"128"`
+	Deposits                 []*phase0.Deposit             `ssz-max:"16"`
+	VoluntaryExits           []*phase0.SignedVoluntaryExit `ssz-max:"16"`
+	SyncAggregate            *altair.SyncAggregate
+	ExecutionPayloadHeader   *BaseExecutionPayloadHeader
+	BLSToExecutionChanges    []*capella.SignedBLSToExecutionChange `ssz-max:"16"`
+	BlobKZGCommitments       []deneb.KZGCommitment                 `ssz-max:"4096" ssz-size:"?,48"`
+	AttesterSlashingsElectra []*electra.AttesterSlashing           `ssz-max:"1"`
+	AttestationsElectra      []*electra.Attestation                `ssz-max:"8"`
+	ExecutionRequests        *electra.ExecutionRequests
 }
 
 func ConstructBlindedBeaconBlock(
@@ -117,6 +122,28 @@ func ConstructBlindedBeaconBlock(
 				BlobKZGCommitments:     blindedBeaconBlock.Body.BlobKZGCommitments,
 			},
 		}
+	case spec.DataVersionElectra.String():
+		res.Electra = &electraApi.BlindedBeaconBlock{
+			Slot:          blindedBeaconBlock.Slot,
+			ProposerIndex: blindedBeaconBlock.ProposerIndex,
+			ParentRoot:    blindedBeaconBlock.ParentRoot,
+			StateRoot:     blindedBeaconBlock.StateRoot,
+			Body: &electraApi.BlindedBeaconBlockBody{
+				RANDAOReveal:           blindedBeaconBlock.Body.RANDAOReveal,
+				ETH1Data:               blindedBeaconBlock.Body.ETH1Data,
+				Graffiti:               blindedBeaconBlock.Body.Graffiti,
+				ProposerSlashings:      blindedBeaconBlock.Body.ProposerSlashings,
+				AttesterSlashings:      blindedBeaconBlock.Body.AttesterSlashingsElectra,
+				Attestations:           blindedBeaconBlock.Body.AttestationsElectra,
+				Deposits:               blindedBeaconBlock.Body.Deposits,
+				VoluntaryExits:         blindedBeaconBlock.Body.VoluntaryExits,
+				SyncAggregate:          blindedBeaconBlock.Body.SyncAggregate,
+				ExecutionPayloadHeader: versionedExecutionPayloadHeader.Electra,
+				BLSToExecutionChanges:  blindedBeaconBlock.Body.BLSToExecutionChanges,
+				BlobKZGCommitments:     blindedBeaconBlock.Body.BlobKZGCommitments,
+				ExecutionRequests:      blindedBeaconBlock.Body.ExecutionRequests,
+			},
+		}
 	default:
 		return res, errors.New("unsupported fork version")
 	}
@@ -255,6 +282,44 @@ func (b *VersionedBlindedBeaconBlock) ToBaseBlindedBeaconBlock() (BaseBlindedBea
 			BLSToExecutionChanges: b.Deneb.Body.BLSToExecutionChanges,
 			BlobKZGCommitments:    b.Deneb.Body.BlobKZGCommitments,
 		}
+	case b.Electra != nil:
+		res.Slot = b.Electra.Slot
+		res.ProposerIndex = b.Electra.ProposerIndex
+		res.ParentRoot = b.Electra.ParentRoot
+		res.StateRoot = b.Electra.StateRoot
+		res.Body = &BaseBlindedBeaconBlockBody{
+			RANDAOReveal:             b.Electra.Body.RANDAOReveal,
+			ETH1Data:                 b.Electra.Body.ETH1Data,
+			Graffiti:                 b.Electra.Body.Graffiti,
+			ProposerSlashings:        b.Electra.Body.ProposerSlashings,
+			AttesterSlashingsElectra: b.Electra.Body.AttesterSlashings,
+			AttestationsElectra:      b.Electra.Body.Attestations,
+			Deposits:                 b.Electra.Body.Deposits,
+			VoluntaryExits:           b.Electra.Body.VoluntaryExits,
+			SyncAggregate:            b.Electra.Body.SyncAggregate,
+			ExecutionPayloadHeader: &BaseExecutionPayloadHeader{
+				ParentHash:       b.Electra.Body.ExecutionPayloadHeader.ParentHash,
+				FeeRecipient:     b.Electra.Body.ExecutionPayloadHeader.FeeRecipient,
+				StateRoot:        b.Electra.Body.ExecutionPayloadHeader.StateRoot,
+				ReceiptsRoot:     b.Electra.Body.ExecutionPayloadHeader.ReceiptsRoot,
+				LogsBloom:        b.Electra.Body.ExecutionPayloadHeader.LogsBloom,
+				PrevRandao:       b.Electra.Body.ExecutionPayloadHeader.PrevRandao,
+				BlockNumber:      b.Electra.Body.ExecutionPayloadHeader.BlockNumber,
+				GasLimit:         b.Electra.Body.ExecutionPayloadHeader.GasLimit,
+				GasUsed:          b.Electra.Body.ExecutionPayloadHeader.GasUsed,
+				Timestamp:        b.Electra.Body.ExecutionPayloadHeader.Timestamp,
+				ExtraData:        b.Electra.Body.ExecutionPayloadHeader.ExtraData,
+				BaseFeePerGas:    b.Electra.Body.ExecutionPayloadHeader.BaseFeePerGas,
+				BlockHash:        b.Electra.Body.ExecutionPayloadHeader.BlockHash,
+				TransactionsRoot: b.Electra.Body.ExecutionPayloadHeader.TransactionsRoot,
+				WithdrawalsRoot:  b.Electra.Body.ExecutionPayloadHeader.WithdrawalsRoot,
+				BlobGasUsed:      b.Electra.Body.ExecutionPayloadHeader.BlobGasUsed,
+				ExcessBlobGas:    b.Electra.Body.ExecutionPayloadHeader.ExcessBlobGas,
+			},
+			BLSToExecutionChanges: b.Electra.Body.BLSToExecutionChanges,
+			BlobKZGCommitments:    b.Electra.Body.BlobKZGCommitments,
+			ExecutionRequests:     b.Electra.Body.ExecutionRequests,
+		}
 	default:
 		return res, errors.New("unsupported fork version")
 	}
@@ -278,15 +343,17 @@ func (b *VersionedBlindedBeaconBlock) ToVersionedBeaconBlock() (VersionedBeaconB
 		ParentRoot:    baseBlindedBeaconBlock.ParentRoot,
 		StateRoot:     baseBlindedBeaconBlock.StateRoot,
 		Body: &BaseBeaconBlockBody{
-			RANDAOReveal:      baseBlindedBeaconBlock.Body.RANDAOReveal,
-			ETH1Data:          baseBlindedBeaconBlock.Body.ETH1Data,
-			Graffiti:          baseBlindedBeaconBlock.Body.Graffiti,
-			ProposerSlashings: baseBlindedBeaconBlock.Body.ProposerSlashings,
-			AttesterSlashings: baseBlindedBeaconBlock.Body.AttesterSlashings,
-			Attestations:      baseBlindedBeaconBlock.Body.Attestations,
-			Deposits:          baseBlindedBeaconBlock.Body.Deposits,
-			VoluntaryExits:    baseBlindedBeaconBlock.Body.VoluntaryExits,
-			SyncAggregate:     baseBlindedBeaconBlock.Body.SyncAggregate,
+			RANDAOReveal:             baseBlindedBeaconBlock.Body.RANDAOReveal,
+			ETH1Data:                 baseBlindedBeaconBlock.Body.ETH1Data,
+			Graffiti:                 baseBlindedBeaconBlock.Body.Graffiti,
+			ProposerSlashings:        baseBlindedBeaconBlock.Body.ProposerSlashings,
+			AttesterSlashings:        baseBlindedBeaconBlock.Body.AttesterSlashings,
+			Attestations:             baseBlindedBeaconBlock.Body.Attestations,
+			AttesterSlashingsElectra: baseBlindedBeaconBlock.Body.AttesterSlashingsElectra,
+			AttestationsElectra:      baseBlindedBeaconBlock.Body.AttestationsElectra,
+			Deposits:                 baseBlindedBeaconBlock.Body.Deposits,
+			VoluntaryExits:           baseBlindedBeaconBlock.Body.VoluntaryExits,
+			SyncAggregate:            baseBlindedBeaconBlock.Body.SyncAggregate,
 			ExecutionPayload: &BaseExecutionPayload{
 				ParentHash:    baseBlindedBeaconBlock.Body.ExecutionPayloadHeader.ParentHash,
 				FeeRecipient:  baseBlindedBeaconBlock.Body.ExecutionPayloadHeader.FeeRecipient,
@@ -308,6 +375,7 @@ func (b *VersionedBlindedBeaconBlock) ToVersionedBeaconBlock() (VersionedBeaconB
 			},
 			BLSToExecutionChanges: baseBlindedBeaconBlock.Body.BLSToExecutionChanges,
 			BlobKZGCommitments:    baseBlindedBeaconBlock.Body.BlobKZGCommitments,
+			ExecutionRequests:     baseBlindedBeaconBlock.Body.ExecutionRequests,
 		},
 	}
 
@@ -319,6 +387,8 @@ func (b *VersionedBlindedBeaconBlock) ToVersionedBeaconBlock() (VersionedBeaconB
 		forkVersion = spec.DataVersionCapella.String()
 	case b.Deneb != nil:
 		forkVersion = spec.DataVersionDeneb.String()
+	case b.Electra != nil:
+		forkVersion = spec.DataVersionElectra.String()
 	default:
 		return res, errors.New("unsupported fork version")
 	}
@@ -339,6 +409,8 @@ func (b *VersionedBlindedBeaconBlock) Version() (string, error) {
 		return spec.DataVersionCapella.String(), nil
 	case b.Deneb != nil:
 		return spec.DataVersionDeneb.String(), nil
+	case b.Electra != nil:
+		return spec.DataVersionElectra.String(), nil
 	default:
 		return "", errors.New("no fork version set")
 	}
@@ -352,6 +424,8 @@ func (b *VersionedBlindedBeaconBlock) VersionNumber() (uint64, error) {
 		return uint64(spec.DataVersionCapella), nil
 	case b.Deneb != nil:
 		return uint64(spec.DataVersionDeneb), nil
+	case b.Electra != nil:
+		return uint64(spec.DataVersionElectra), nil
 	default:
 		return 0, errors.New("no fork version set")
 	}
@@ -1,26 +1,46 @@
 package common
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 
 	ssz "github.com/ferranbt/fastssz"
 
+	"github.com/attestantio/go-eth2-client/spec"
+
 	denebApi "github.com/attestantio/go-builder-client/api/deneb"
 	bellatrix "github.com/attestantio/go-eth2-client/spec/bellatrix"
 	capella "github.com/attestantio/go-eth2-client/spec/capella"
 	deneb "github.com/attestantio/go-eth2-client/spec/deneb"
+	electra "github.com/attestantio/go-eth2-client/spec/electra"
 )
 
+// VersionedExecutionPayload holds the execution payload of a single fork version. Electra reuses
+// the Deneb execution payload container, so an Electra payload is encoded together with its execution
+// requests as {execution_payload, execution_requests}, which also tells it apart from a Deneb payload
+// when decoding without a fork version. Hash tree roots are those of the execution payload alone
 type VersionedExecutionPayload struct {
 	Bellatrix *bellatrix.ExecutionPayload `json:"bellatrix,omitempty"`
 	Capella   *capella.ExecutionPayload   `json:"capella,omitempty"`
 	Deneb     *deneb.ExecutionPayload     `json:"deneb,omitempty"`
+	Electra   *deneb.ExecutionPayload     `json:"electra,omitempty"`
+	// ExecutionRequests are the requests produced alongside the Electra payload, as
+	// the Electra payload container does not hold them
+	ExecutionRequests *electra.ExecutionRequests `json:"execution_requests,omitempty"`
 }
 
+// VersionedExecutionPayloadV2 holds the execution payload of a single fork version along with its
+// blobs bundle from Deneb onwards. An Electra payload is encoded as {execution_payload, blobs_bundle,
+// execution_requests}, and hash tree roots are those of the execution payload alone
 type VersionedExecutionPayloadV2 struct {
 	Bellatrix *bellatrix.ExecutionPayload              `json:"bellatrix,omitempty"`
 	Capella   *capella.ExecutionPayload                `json:"capella,omitempty"`
 	Deneb     *denebApi.ExecutionPayloadAndBlobsBundle `json:"deneb,omitempty"`
+	Electra   *denebApi.ExecutionPayloadAndBlobsBundle `json:"electra,omitempty"`
+	// ExecutionRequests are the requests produced alongside the Electra payload, as
+	// the Electra payload container does not hold them
+	ExecutionRequests *electra.ExecutionRequests `json:"execution_requests,omitempty"`
 }
 
 type VersionedExecutionPayloadWithVersionNumber struct {
@@ -43,7 +63,25 @@ type VersionedExecutionPayloadV2WithVersionName struct {
 	VersionedExecutionPayload *VersionedExecutionPayloadV2 `json:"data"`
 }
 
+func (v *VersionedExecutionPayload) electraContainer() *electraExecutionPayload {
+	return &electraExecutionPayload{
+		ExecutionPayload:  v.Electra,
+		ExecutionRequests: v.ExecutionRequests,
+	}
+}
+
+func (v *VersionedExecutionPayloadV2) electraContainer() *electraExecutionPayloadAndBlobsBundle {
+	return &electraExecutionPayloadAndBlobsBundle{
+		ExecutionPayload:  v.Electra.ExecutionPayload,
+		BlobsBundle:       v.Electra.BlobsBundle,
+		ExecutionRequests: v.ExecutionRequests,
+	}
+}
+
 func (v *VersionedExecutionPayload) GetTree() (*ssz.Node, error) {
+	if v.Electra != nil {
+		return v.Electra.GetTree()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.GetTree()
 	}
@@ -57,6 +95,9 @@ func (v *VersionedExecutionPayload) GetTree() (*ssz.Node, error) {
 }
 
 func (v *VersionedExecutionPayloadV2) GetTree() (*ssz.Node, error) {
+	if v.Electra != nil {
+		return v.Electra.GetTree()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.GetTree()
 	}
@@ -70,6 +111,9 @@ func (v *VersionedExecutionPayloadV2) GetTree() (*ssz.Node, error) {
 }
 
 func (v *VersionedExecutionPayload) HashTreeRoot() ([32]byte, error) {
+	if v.Electra != nil {
+		return v.Electra.HashTreeRoot()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.HashTreeRoot()
 	}
@@ -83,6 +127,9 @@ func (v *VersionedExecutionPayload) HashTreeRoot() ([32]byte, error) {
 }
 
 func (v *VersionedExecutionPayloadV2) HashTreeRoot() ([32]byte, error) {
+	if v.Electra != nil {
+		return v.Electra.HashTreeRoot()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.HashTreeRoot()
 	}
@@ -96,6 +143,9 @@ func (v *VersionedExecutionPayloadV2) HashTreeRoot() ([32]byte, error) {
 }
 
 func (v *VersionedExecutionPayload) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	if v.Electra != nil {
+		return v.Electra.HashTreeRootWith(hh)
+	}
 	if v.Deneb != nil {
 		return v.Deneb.HashTreeRootWith(hh)
 	}
@@ -109,6 +159,9 @@ func (v *VersionedExecutionPayload) HashTreeRootWith(hh ssz.HashWalker) (err err
 }
 
 func (v *VersionedExecutionPayloadV2) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	if v.Electra != nil {
+		return v.Electra.HashTreeRootWith(hh)
+	}
 	if v.Deneb != nil {
 		return v.Deneb.HashTreeRootWith(hh)
 	}
@@ -122,6 +175,9 @@ func (v *VersionedExecutionPayloadV2) HashTreeRootWith(hh ssz.HashWalker) (err e
 }
 
 func (v *VersionedExecutionPayload) MarshalJSON() ([]byte, error) {
+	if v.Electra != nil {
+		return v.electraContainer().MarshalJSON()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.MarshalJSON()
 	}
@@ -135,6 +191,9 @@ func (v *VersionedExecutionPayload) MarshalJSON() ([]byte, error) {
 }
 
 func (v *VersionedExecutionPayloadV2) MarshalJSON() ([]byte, error) {
+	if v.Electra != nil {
+		return v.electraContainer().MarshalJSON()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.MarshalJSON()
 	}
@@ -148,6 +207,9 @@ func (v *VersionedExecutionPayloadV2) MarshalJSON() ([]byte, error) {
 }
 
 func (v *VersionedExecutionPayload) MarshalSSZ() ([]byte, error) {
+	if v.Electra != nil {
+		return v.electraContainer().MarshalSSZ()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.MarshalSSZ()
 	}
@@ -161,6 +223,9 @@ func (v *VersionedExecutionPayload) MarshalSSZ() ([]byte, error) {
 }
 
 func (v *VersionedExecutionPayloadV2) MarshalSSZ() ([]byte, error) {
+	if v.Electra != nil {
+		return v.electraContainer().MarshalSSZ()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.MarshalSSZ()
 	}
@@ -174,6 +239,9 @@ func (v *VersionedExecutionPayloadV2) MarshalSSZ() ([]byte, error) {
 }
 
 func (v *VersionedExecutionPayload) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if v.Electra != nil {
+		return v.electraContainer().MarshalSSZTo(buf)
+	}
 	if v.Deneb != nil {
 		return v.Deneb.MarshalSSZTo(buf)
 	}
@@ -187,6 +255,9 @@ func (v *VersionedExecutionPayload) MarshalSSZTo(buf []byte) (dst []byte, err er
 }
 
 func (v *VersionedExecutionPayloadV2) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if v.Electra != nil {
+		return v.electraContainer().MarshalSSZTo(buf)
+	}
 	if v.Deneb != nil {
 		return v.Deneb.MarshalSSZTo(buf)
 	}
@@ -200,6 +271,9 @@ func (v *VersionedExecutionPayloadV2) MarshalSSZTo(buf []byte) (dst []byte, err
 }
 
 func (v *VersionedExecutionPayload) MarshalYAML() ([]byte, error) {
+	if v.Electra != nil {
+		return v.electraContainer().MarshalYAML()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.MarshalYAML()
 	}
@@ -213,6 +287,9 @@ func (v *VersionedExecutionPayload) MarshalYAML() ([]byte, error) {
 }
 
 func (v *VersionedExecutionPayloadV2) MarshalYAML() ([]byte, error) {
+	if v.Electra != nil {
+		return v.electraContainer().MarshalYAML()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.MarshalYAML()
 	}
@@ -226,6 +303,9 @@ func (v *VersionedExecutionPayloadV2) MarshalYAML() ([]byte, error) {
 }
 
 func (v *VersionedExecutionPayload) SizeSSZ() (size int) {
+	if v.Electra != nil {
+		return v.electraContainer().SizeSSZ()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.SizeSSZ()
 	}
@@ -239,6 +319,9 @@ func (v *VersionedExecutionPayload) SizeSSZ() (size int) {
 }
 
 func (v *VersionedExecutionPayloadV2) SizeSSZ() (size int) {
+	if v.Electra != nil {
+		return v.electraContainer().SizeSSZ()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.SizeSSZ()
 	}
@@ -252,6 +335,9 @@ func (v *VersionedExecutionPayloadV2) SizeSSZ() (size int) {
 }
 
 func (v *VersionedExecutionPayload) String() string {
+	if v.Electra != nil {
+		return v.Electra.String()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.String()
 	}
@@ -265,6 +351,9 @@ func (v *VersionedExecutionPayload) String() string {
 }
 
 func (v *VersionedExecutionPayloadV2) String() string {
+	if v.Electra != nil {
+		return v.Electra.String()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.String()
 	}
@@ -283,9 +372,18 @@ func (v *VersionedExecutionPayload) UnmarshalJSON(input []byte) error {
 
 	// Type is forkversion naive so we need to try each type in reverse
 	// fork version order.  This is because the fork version is not
-	// included in the JSON data.
+	// included in the JSON data. Prefer UnmarshalJSONWithVersion when
+	// the fork version is known.
 	var err error
 
+	electraPayload := &electraExecutionPayload{}
+	err = electraPayload.UnmarshalJSON(input)
+	if err == nil {
+		v.Electra = electraPayload.ExecutionPayload
+		v.ExecutionRequests = electraPayload.ExecutionRequests
+		return nil
+	}
+
 	v.Deneb = &deneb.ExecutionPayload{}
 	err = v.Deneb.UnmarshalJSON(input)
 	if err == nil {
@@ -317,9 +415,21 @@ func (v *VersionedExecutionPayloadV2) UnmarshalJSON(input []byte) error {
 
 	// Type is forkversion naive so we need to try each type in reverse
 	// fork version order.  This is because the fork version is not
-	// included in the JSON data.
+	// included in the JSON data. Prefer UnmarshalJSONWithVersion when
+	// the fork version is known.
 	var err error
 
+	electraPayload := &electraExecutionPayloadAndBlobsBundle{}
+	err = electraPayload.UnmarshalJSON(input)
+	if err == nil {
+		v.Electra = &denebApi.ExecutionPayloadAndBlobsBundle{
+			ExecutionPayload: electraPayload.ExecutionPayload,
+			BlobsBundle:      electraPayload.BlobsBundle,
+		}
+		v.ExecutionRequests = electraPayload.ExecutionRequests
+		return nil
+	}
+
 	v.Deneb = &denebApi.ExecutionPayloadAndBlobsBundle{}
 	err = v.Deneb.UnmarshalJSON(input)
 	if err == nil {
@@ -328,14 +438,17 @@ func (v *VersionedExecutionPayloadV2) UnmarshalJSON(input []byte) error {
 	v.Deneb = nil
 
 	// else create a v1 object and try to unmarshal
-	// it would try the deneb payload without blobs
-	// and older fork versions
+	// older fork versions, which have no blobs. Deneb
+	// onwards the payload must come with its blobs
+	// bundle, as when decoding with a known version
 	v1 := &VersionedExecutionPayload{}
 	err = v1.UnmarshalJSON(input)
 	if err == nil {
+		if v1.Deneb != nil || v1.Electra != nil {
+			return errors.New("ExecutionPayload without blobs bundle")
+		}
 		// convert to v2
 		v2, err := v1.ToVersionedExecutionPayloadV2()
-		// if it was a v1 deneb object without blobs, it would have the v2 payload without blobsbundle
 		if err != nil {
 			return err
 		}
@@ -353,9 +466,18 @@ func (v *VersionedExecutionPayload) UnmarshalSSZ(buf []byte) error {
 
 	// Type is forkversion naive so we need to try each type in reverse
 	// fork version order.  This is because the fork version is not
-	// included in the SSZ data.
+	// included in the SSZ data. Prefer UnmarshalSSZWithVersion when
+	// the fork version is known.
 	var err error
 
+	electraPayload := &electraExecutionPayload{}
+	err = electraPayload.UnmarshalSSZ(buf)
+	if err == nil {
+		v.Electra = electraPayload.ExecutionPayload
+		v.ExecutionRequests = electraPayload.ExecutionRequests
+		return nil
+	}
+
 	v.Deneb = &deneb.ExecutionPayload{}
 	err = v.Deneb.UnmarshalSSZ(buf)
 	if err == nil {
@@ -387,9 +509,21 @@ func (v *VersionedExecutionPayloadV2) UnmarshalSSZ(buf []byte) error {
 
 	// Type is forkversion naive so we need to try each type in reverse
 	// fork version order.  This is because the fork version is not
-	// included in the SSZ data.
+	// included in the SSZ data. Prefer UnmarshalSSZWithVersion when
+	// the fork version is known.
 	var err error
 
+	electraPayload := &electraExecutionPayloadAndBlobsBundle{}
+	err = electraPayload.UnmarshalSSZ(buf)
+	if err == nil {
+		v.Electra = &denebApi.ExecutionPayloadAndBlobsBundle{
+			ExecutionPayload: electraPayload.ExecutionPayload,
+			BlobsBundle:      electraPayload.BlobsBundle,
+		}
+		v.ExecutionRequests = electraPayload.ExecutionRequests
+		return nil
+	}
+
 	v.Deneb = &denebApi.ExecutionPayloadAndBlobsBundle{}
 	err = v.Deneb.UnmarshalSSZ(buf)
 	if err == nil {
@@ -398,14 +532,17 @@ func (v *VersionedExecutionPayloadV2) UnmarshalSSZ(buf []byte) error {
 	v.Deneb = nil
 
 	// else create a v1 object and try to unmarshal
-	// it would try the deneb payload without blobs
-	// and older fork versions
+	// older fork versions, which have no blobs. Deneb
+	// onwards the payload must come with its blobs
+	// bundle, as when decoding with a known version
 	v1 := &VersionedExecutionPayload{}
 	err = v1.UnmarshalSSZ(buf)
 	if err == nil {
+		if v1.Deneb != nil || v1.Electra != nil {
+			return errors.New("ExecutionPayload without blobs bundle")
+		}
 		// convert to v2
 		v2, err := v1.ToVersionedExecutionPayloadV2()
-		// if it was a v1 deneb object without blobs, it would have the v2 payload without blobsbundle
 		if err != nil {
 			return err
 		}
@@ -426,6 +563,14 @@ func (v *VersionedExecutionPayload) UnmarshalYAML(input []byte) error {
 	// included in the YAML data.
 	var err error
 
+	electraPayload := &electraExecutionPayload{}
+	err = electraPayload.UnmarshalYAML(input)
+	if err == nil {
+		v.Electra = electraPayload.ExecutionPayload
+		v.ExecutionRequests = electraPayload.ExecutionRequests
+		return nil
+	}
+
 	v.Deneb = &deneb.ExecutionPayload{}
 	err = v.Deneb.UnmarshalYAML(input)
 	if err == nil {
@@ -460,6 +605,17 @@ func (v *VersionedExecutionPayloadV2) UnmarshalYAML(input []byte) error {
 	// included in the YAML data.
 	var err error
 
+	electraPayload := &electraExecutionPayloadAndBlobsBundle{}
+	err = electraPayload.UnmarshalYAML(input)
+	if err == nil {
+		v.Electra = &denebApi.ExecutionPayloadAndBlobsBundle{
+			ExecutionPayload: electraPayload.ExecutionPayload,
+			BlobsBundle:      electraPayload.BlobsBundle,
+		}
+		v.ExecutionRequests = electraPayload.ExecutionRequests
+		return nil
+	}
+
 	v.Deneb = &denebApi.ExecutionPayloadAndBlobsBundle{}
 	err = v.Deneb.UnmarshalYAML(input)
 	if err == nil {
@@ -468,14 +624,17 @@ func (v *VersionedExecutionPayloadV2) UnmarshalYAML(input []byte) error {
 	v.Deneb = nil
 
 	// else create a v1 object and try to unmarshal
-	// it would try the deneb payload without blobs
-	// and older fork versions
+	// older fork versions, which have no blobs. Deneb
+	// onwards the payload must come with its blobs
+	// bundle, as when decoding with a known version
 	v1 := &VersionedExecutionPayload{}
 	err = v1.UnmarshalYAML(input)
 	if err == nil {
+		if v1.Deneb != nil || v1.Electra != nil {
+			return errors.New("ExecutionPayload without blobs bundle")
+		}
 		// convert to v2
 		v2, err := v1.ToVersionedExecutionPayloadV2()
-		// if it was a v1 deneb object without blobs, it would have the v2 payload without blobsbundle
 		if err != nil {
 			return err
 		}
@@ -486,3 +645,273 @@ func (v *VersionedExecutionPayloadV2) UnmarshalYAML(input []byte) error {
 	return errors.New("unsupported ExecutionPayload type")
 
 }
+
+// UnmarshalJSONWithVersion decodes JSON data as the given fork version instead of
+// trying each fork version in turn, as the version is known by the caller
+func (v *VersionedExecutionPayload) UnmarshalJSONWithVersion(version spec.DataVersion, input []byte) error {
+	res := VersionedExecutionPayload{}
+
+	switch version {
+	case spec.DataVersionBellatrix:
+		res.Bellatrix = &bellatrix.ExecutionPayload{}
+		if err := res.Bellatrix.UnmarshalJSON(input); err != nil {
+			return err
+		}
+	case spec.DataVersionCapella:
+		res.Capella = &capella.ExecutionPayload{}
+		if err := res.Capella.UnmarshalJSON(input); err != nil {
+			return err
+		}
+	case spec.DataVersionDeneb:
+		res.Deneb = &deneb.ExecutionPayload{}
+		if err := res.Deneb.UnmarshalJSON(input); err != nil {
+			return err
+		}
+	case spec.DataVersionElectra:
+		electraPayload := &electraExecutionPayload{}
+		if err := electraPayload.UnmarshalJSON(input); err != nil {
+			return err
+		}
+		res.Electra = electraPayload.ExecutionPayload
+		res.ExecutionRequests = electraPayload.ExecutionRequests
+	default:
+		return fmt.Errorf("unsupported ExecutionPayload version %s", version)
+	}
+
+	*v = res
+	return nil
+}
+
+// UnmarshalSSZWithVersion decodes SSZ data as the given fork version instead of
+// trying each fork version in turn, as the version is known by the caller
+func (v *VersionedExecutionPayload) UnmarshalSSZWithVersion(version spec.DataVersion, buf []byte) error {
+	res := VersionedExecutionPayload{}
+
+	switch version {
+	case spec.DataVersionBellatrix:
+		res.Bellatrix = &bellatrix.ExecutionPayload{}
+		if err := res.Bellatrix.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	case spec.DataVersionCapella:
+		res.Capella = &capella.ExecutionPayload{}
+		if err := res.Capella.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	case spec.DataVersionDeneb:
+		res.Deneb = &deneb.ExecutionPayload{}
+		if err := res.Deneb.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	case spec.DataVersionElectra:
+		electraPayload := &electraExecutionPayload{}
+		if err := electraPayload.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+		res.Electra = electraPayload.ExecutionPayload
+		res.ExecutionRequests = electraPayload.ExecutionRequests
+	default:
+		return fmt.Errorf("unsupported ExecutionPayload version %s", version)
+	}
+
+	*v = res
+	return nil
+}
+
+// UnmarshalJSON decodes the data using the declared version
+func (v *VersionedExecutionPayloadWithVersionNumber) UnmarshalJSON(input []byte) error {
+	var data struct {
+		VersionNumber uint64          `json:"version,string"`
+		Data          json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(input, &data); err != nil {
+		return err
+	}
+
+	v.VersionNumber = data.VersionNumber
+	v.VersionedExecutionPayload = nil
+	if len(data.Data) == 0 || string(data.Data) == "null" {
+		return nil
+	}
+
+	version := spec.DataVersion(data.VersionNumber)
+
+	res := &VersionedExecutionPayload{}
+	if err := res.UnmarshalJSONWithVersion(version, data.Data); err != nil {
+		return err
+	}
+	v.VersionedExecutionPayload = res
+
+	return nil
+}
+
+// UnmarshalJSON decodes the data using the declared version
+func (v *VersionedExecutionPayloadWithVersionName) UnmarshalJSON(input []byte) error {
+	var data struct {
+		VersionName string          `json:"version"`
+		Data        json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(input, &data); err != nil {
+		return err
+	}
+
+	v.VersionName = data.VersionName
+	v.VersionedExecutionPayload = nil
+	if len(data.Data) == 0 || string(data.Data) == "null" {
+		return nil
+	}
+
+	version, err := DataVersionFromName(data.VersionName)
+	if err != nil {
+		return err
+	}
+
+	res := &VersionedExecutionPayload{}
+	if err := res.UnmarshalJSONWithVersion(version, data.Data); err != nil {
+		return err
+	}
+	v.VersionedExecutionPayload = res
+
+	return nil
+}
+
+// UnmarshalJSONWithVersion decodes JSON data as the given fork version instead of
+// trying each fork version in turn, as the version is known by the caller
+func (v *VersionedExecutionPayloadV2) UnmarshalJSONWithVersion(version spec.DataVersion, input []byte) error {
+	// Deneb onwards the payload must come with its blobs bundle, so there is
+	// no fallback to a v1 payload that would drop the blobs
+	switch version {
+	case spec.DataVersionDeneb:
+		bundle := &denebApi.ExecutionPayloadAndBlobsBundle{}
+		if err := bundle.UnmarshalJSON(input); err != nil {
+			return fmt.Errorf("invalid %s execution payload and blobs bundle: %w", version, err)
+		}
+		*v = VersionedExecutionPayloadV2{Deneb: bundle}
+		return nil
+	case spec.DataVersionElectra:
+		electraPayload := &electraExecutionPayloadAndBlobsBundle{}
+		if err := electraPayload.UnmarshalJSON(input); err != nil {
+			return fmt.Errorf("invalid %s execution payload, blobs bundle and execution requests: %w", version, err)
+		}
+		*v = VersionedExecutionPayloadV2{
+			Electra: &denebApi.ExecutionPayloadAndBlobsBundle{
+				ExecutionPayload: electraPayload.ExecutionPayload,
+				BlobsBundle:      electraPayload.BlobsBundle,
+			},
+			ExecutionRequests: electraPayload.ExecutionRequests,
+		}
+		return nil
+	}
+
+	// Versions before deneb have no blobs and are decoded as a v1 payload
+	v1 := &VersionedExecutionPayload{}
+	if err := v1.UnmarshalJSONWithVersion(version, input); err != nil {
+		return err
+	}
+	v2, err := v1.ToVersionedExecutionPayloadV2()
+	if err != nil {
+		return err
+	}
+	*v = v2
+
+	return nil
+}
+
+// UnmarshalSSZWithVersion decodes SSZ data as the given fork version instead of
+// trying each fork version in turn, as the version is known by the caller
+func (v *VersionedExecutionPayloadV2) UnmarshalSSZWithVersion(version spec.DataVersion, buf []byte) error {
+	// Deneb onwards the payload must come with its blobs bundle, so there is
+	// no fallback to a v1 payload that would drop the blobs
+	switch version {
+	case spec.DataVersionDeneb:
+		bundle := &denebApi.ExecutionPayloadAndBlobsBundle{}
+		if err := bundle.UnmarshalSSZ(buf); err != nil {
+			return fmt.Errorf("invalid %s execution payload and blobs bundle: %w", version, err)
+		}
+		*v = VersionedExecutionPayloadV2{Deneb: bundle}
+		return nil
+	case spec.DataVersionElectra:
+		electraPayload := &electraExecutionPayloadAndBlobsBundle{}
+		if err := electraPayload.UnmarshalSSZ(buf); err != nil {
+			return fmt.Errorf("invalid %s execution payload, blobs bundle and execution requests: %w", version, err)
+		}
+		*v = VersionedExecutionPayloadV2{
+			Electra: &denebApi.ExecutionPayloadAndBlobsBundle{
+				ExecutionPayload: electraPayload.ExecutionPayload,
+				BlobsBundle:      electraPayload.BlobsBundle,
+			},
+			ExecutionRequests: electraPayload.ExecutionRequests,
+		}
+		return nil
+	}
+
+	// Versions before deneb have no blobs and are decoded as a v1 payload
+	v1 := &VersionedExecutionPayload{}
+	if err := v1.UnmarshalSSZWithVersion(version, buf); err != nil {
+		return err
+	}
+	v2, err := v1.ToVersionedExecutionPayloadV2()
+	if err != nil {
+		return err
+	}
+	*v = v2
+
+	return nil
+}
+
+// UnmarshalJSON decodes the data using the declared version
+func (v *VersionedExecutionPayloadV2WithVersionNumber) UnmarshalJSON(input []byte) error {
+	var data struct {
+		VersionNumber uint64          `json:"version,string"`
+		Data          json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(input, &data); err != nil {
+		return err
+	}
+
+	v.VersionNumber = data.VersionNumber
+	v.VersionedExecutionPayload = nil
+	if len(data.Data) == 0 || string(data.Data) == "null" {
+		return nil
+	}
+
+	version := spec.DataVersion(data.VersionNumber)
+
+	res := &VersionedExecutionPayloadV2{}
+	if err := res.UnmarshalJSONWithVersion(version, data.Data); err != nil {
+		return err
+	}
+	v.VersionedExecutionPayload = res
+
+	return nil
+}
+
+// UnmarshalJSON decodes the data using the declared version
+func (v *VersionedExecutionPayloadV2WithVersionName) UnmarshalJSON(input []byte) error {
+	var data struct {
+		VersionName string          `json:"version"`
+		Data        json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(input, &data); err != nil {
+		return err
+	}
+
+	v.VersionName = data.VersionName
+	v.VersionedExecutionPayload = nil
+	if len(data.Data) == 0 || string(data.Data) == "null" {
+		return nil
+	}
+
+	version, err := DataVersionFromName(data.VersionName)
+	if err != nil {
+		return err
+	}
+
+	res := &VersionedExecutionPayloadV2{}
+	if err := res.UnmarshalJSONWithVersion(version, data.Data); err != nil {
+		return err
+	}
+	v.VersionedExecutionPayload = res
+
+	return nil
+}
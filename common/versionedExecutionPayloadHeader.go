@@ -1,10 +1,14 @@
 package common
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 
 	ssz "github.com/ferranbt/fastssz"
 
+	"github.com/attestantio/go-eth2-client/spec"
+
 	bellatrix "github.com/attestantio/go-eth2-client/spec/bellatrix"
 	capella "github.com/attestantio/go-eth2-client/spec/capella"
 	deneb "github.com/attestantio/go-eth2-client/spec/deneb"
@@ -14,6 +18,7 @@ type VersionedExecutionPayloadHeader struct {
 	Bellatrix *bellatrix.ExecutionPayloadHeader `json:"bellatrix,omitempty"`
 	Capella   *capella.ExecutionPayloadHeader   `json:"capella,omitempty"`
 	Deneb     *deneb.ExecutionPayloadHeader     `json:"deneb,omitempty"`
+	Electra   *deneb.ExecutionPayloadHeader     `json:"electra,omitempty"`
 }
 
 type VersionedExecutionPayloadHeaderWithVersionNumber struct {
@@ -27,6 +32,9 @@ type VersionedExecutionPayloadHeaderWithVersionName struct {
 }
 
 func (v *VersionedExecutionPayloadHeader) GetTree() (*ssz.Node, error) {
+	if v.Electra != nil {
+		return v.Electra.GetTree()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.GetTree()
 	}
@@ -40,6 +48,9 @@ func (v *VersionedExecutionPayloadHeader) GetTree() (*ssz.Node, error) {
 }
 
 func (v *VersionedExecutionPayloadHeader) HashTreeRoot() ([32]byte, error) {
+	if v.Electra != nil {
+		return v.Electra.HashTreeRoot()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.HashTreeRoot()
 	}
@@ -53,6 +64,9 @@ func (v *VersionedExecutionPayloadHeader) HashTreeRoot() ([32]byte, error) {
 }
 
 func (v *VersionedExecutionPayloadHeader) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	if v.Electra != nil {
+		return v.Electra.HashTreeRootWith(hh)
+	}
 	if v.Deneb != nil {
 		return v.Deneb.HashTreeRootWith(hh)
 	}
@@ -66,6 +80,9 @@ func (v *VersionedExecutionPayloadHeader) HashTreeRootWith(hh ssz.HashWalker) (e
 }
 
 func (v *VersionedExecutionPayloadHeader) MarshalJSON() ([]byte, error) {
+	if v.Electra != nil {
+		return v.Electra.MarshalJSON()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.MarshalJSON()
 	}
@@ -79,6 +96,9 @@ func (v *VersionedExecutionPayloadHeader) MarshalJSON() ([]byte, error) {
 }
 
 func (v *VersionedExecutionPayloadHeader) MarshalSSZ() ([]byte, error) {
+	if v.Electra != nil {
+		return v.Electra.MarshalSSZ()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.MarshalSSZ()
 	}
@@ -92,6 +112,9 @@ func (v *VersionedExecutionPayloadHeader) MarshalSSZ() ([]byte, error) {
 }
 
 func (v *VersionedExecutionPayloadHeader) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if v.Electra != nil {
+		return v.Electra.MarshalSSZTo(buf)
+	}
 	if v.Deneb != nil {
 		return v.Deneb.MarshalSSZTo(buf)
 	}
@@ -105,6 +128,9 @@ func (v *VersionedExecutionPayloadHeader) MarshalSSZTo(buf []byte) (dst []byte,
 }
 
 func (v *VersionedExecutionPayloadHeader) MarshalYAML() ([]byte, error) {
+	if v.Electra != nil {
+		return v.Electra.MarshalYAML()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.MarshalYAML()
 	}
@@ -118,6 +144,9 @@ func (v *VersionedExecutionPayloadHeader) MarshalYAML() ([]byte, error) {
 }
 
 func (v *VersionedExecutionPayloadHeader) SizeSSZ() (size int) {
+	if v.Electra != nil {
+		return v.Electra.SizeSSZ()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.SizeSSZ()
 	}
@@ -131,6 +160,9 @@ func (v *VersionedExecutionPayloadHeader) SizeSSZ() (size int) {
 }
 
 func (v *VersionedExecutionPayloadHeader) String() string {
+	if v.Electra != nil {
+		return v.Electra.String()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.String()
 	}
@@ -149,7 +181,10 @@ func (v *VersionedExecutionPayloadHeader) UnmarshalJSON(input []byte) error {
 
 	// Type is forkversion naive so we need to try each type in reverse
 	// fork version order.  This is because the fork version is not
-	// included in the JSON data.
+	// included in the JSON data. Prefer UnmarshalJSONWithVersion when
+	// the fork version is known.
+	// Electra reuses the Deneb execution payload header container, so an
+	// Electra header cannot be told apart from a Deneb one and is decoded as Deneb.
 	var err error
 
 	v.Deneb = &deneb.ExecutionPayloadHeader{}
@@ -183,7 +218,10 @@ func (v *VersionedExecutionPayloadHeader) UnmarshalSSZ(buf []byte) error {
 
 	// Type is forkversion naive so we need to try each type in reverse
 	// fork version order.  This is because the fork version is not
-	// included in the SSZ data.
+	// included in the SSZ data. Prefer UnmarshalSSZWithVersion when
+	// the fork version is known.
+	// Electra reuses the Deneb execution payload header container, so an
+	// Electra header cannot be told apart from a Deneb one and is decoded as Deneb.
 	var err error
 
 	v.Deneb = &deneb.ExecutionPayloadHeader{}
@@ -218,6 +256,8 @@ func (v *VersionedExecutionPayloadHeader) UnmarshalYAML(input []byte) error {
 	// Type is forkversion naive so we need to try each type in reverse
 	// fork version order.  This is because the fork version is not
 	// included in the YAML data.
+	// Electra reuses the Deneb execution payload header container, so an
+	// Electra header cannot be told apart from a Deneb one and is decoded as Deneb.
 	var err error
 
 	v.Deneb = &deneb.ExecutionPayloadHeader{}
@@ -244,3 +284,128 @@ func (v *VersionedExecutionPayloadHeader) UnmarshalYAML(input []byte) error {
 	return errors.New("unsupported ExecutionPayloadHeader type")
 
 }
+
+// UnmarshalJSONWithVersion decodes JSON data as the given fork version instead of
+// trying each fork version in turn, as the version is known by the caller
+func (v *VersionedExecutionPayloadHeader) UnmarshalJSONWithVersion(version spec.DataVersion, input []byte) error {
+	res := VersionedExecutionPayloadHeader{}
+
+	switch version {
+	case spec.DataVersionBellatrix:
+		res.Bellatrix = &bellatrix.ExecutionPayloadHeader{}
+		if err := res.Bellatrix.UnmarshalJSON(input); err != nil {
+			return err
+		}
+	case spec.DataVersionCapella:
+		res.Capella = &capella.ExecutionPayloadHeader{}
+		if err := res.Capella.UnmarshalJSON(input); err != nil {
+			return err
+		}
+	case spec.DataVersionDeneb:
+		res.Deneb = &deneb.ExecutionPayloadHeader{}
+		if err := res.Deneb.UnmarshalJSON(input); err != nil {
+			return err
+		}
+	case spec.DataVersionElectra:
+		res.Electra = &deneb.ExecutionPayloadHeader{}
+		if err := res.Electra.UnmarshalJSON(input); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported ExecutionPayloadHeader version %s", version)
+	}
+
+	*v = res
+	return nil
+}
+
+// UnmarshalSSZWithVersion decodes SSZ data as the given fork version instead of
+// trying each fork version in turn, as the version is known by the caller
+func (v *VersionedExecutionPayloadHeader) UnmarshalSSZWithVersion(version spec.DataVersion, buf []byte) error {
+	res := VersionedExecutionPayloadHeader{}
+
+	switch version {
+	case spec.DataVersionBellatrix:
+		res.Bellatrix = &bellatrix.ExecutionPayloadHeader{}
+		if err := res.Bellatrix.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	case spec.DataVersionCapella:
+		res.Capella = &capella.ExecutionPayloadHeader{}
+		if err := res.Capella.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	case spec.DataVersionDeneb:
+		res.Deneb = &deneb.ExecutionPayloadHeader{}
+		if err := res.Deneb.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	case spec.DataVersionElectra:
+		res.Electra = &deneb.ExecutionPayloadHeader{}
+		if err := res.Electra.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported ExecutionPayloadHeader version %s", version)
+	}
+
+	*v = res
+	return nil
+}
+
+// UnmarshalJSON decodes the data using the declared version
+func (v *VersionedExecutionPayloadHeaderWithVersionNumber) UnmarshalJSON(input []byte) error {
+	var data struct {
+		VersionNumber uint64          `json:"version,string"`
+		Data          json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(input, &data); err != nil {
+		return err
+	}
+
+	v.VersionNumber = data.VersionNumber
+	v.VersionedExecutionPayloadHeader = nil
+	if len(data.Data) == 0 || string(data.Data) == "null" {
+		return nil
+	}
+
+	version := spec.DataVersion(data.VersionNumber)
+
+	res := &VersionedExecutionPayloadHeader{}
+	if err := res.UnmarshalJSONWithVersion(version, data.Data); err != nil {
+		return err
+	}
+	v.VersionedExecutionPayloadHeader = res
+
+	return nil
+}
+
+// UnmarshalJSON decodes the data using the declared version
+func (v *VersionedExecutionPayloadHeaderWithVersionName) UnmarshalJSON(input []byte) error {
+	var data struct {
+		VersionName string          `json:"version"`
+		Data        json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(input, &data); err != nil {
+		return err
+	}
+
+	v.VersionName = data.VersionName
+	v.VersionedExecutionPayloadHeader = nil
+	if len(data.Data) == 0 || string(data.Data) == "null" {
+		return nil
+	}
+
+	version, err := DataVersionFromName(data.VersionName)
+	if err != nil {
+		return err
+	}
+
+	res := &VersionedExecutionPayloadHeader{}
+	if err := res.UnmarshalJSONWithVersion(version, data.Data); err != nil {
+		return err
+	}
+	v.VersionedExecutionPayloadHeader = res
+
+	return nil
+}
@@ -115,6 +115,28 @@ func ConstructExecutionPayloadHeader(
 			ExcessBlobGas:    executionPayloadHeader.ExcessBlobGas,
 		}
 
+	case spec.DataVersionElectra.String():
+		// Electra reuses the deneb execution payload header
+		res.Electra = &deneb.ExecutionPayloadHeader{
+			ParentHash:       executionPayloadHeader.ParentHash,
+			FeeRecipient:     executionPayloadHeader.FeeRecipient,
+			StateRoot:        executionPayloadHeader.StateRoot,
+			ReceiptsRoot:     executionPayloadHeader.ReceiptsRoot,
+			LogsBloom:        executionPayloadHeader.LogsBloom,
+			PrevRandao:       executionPayloadHeader.PrevRandao,
+			BlockNumber:      executionPayloadHeader.BlockNumber,
+			GasLimit:         executionPayloadHeader.GasLimit,
+			GasUsed:          executionPayloadHeader.GasUsed,
+			Timestamp:        executionPayloadHeader.Timestamp,
+			ExtraData:        executionPayloadHeader.ExtraData,
+			BaseFeePerGas:    executionPayloadHeader.BaseFeePerGas,
+			BlockHash:        executionPayloadHeader.BlockHash,
+			TransactionsRoot: executionPayloadHeader.TransactionsRoot,
+			WithdrawalsRoot:  executionPayloadHeader.WithdrawalsRoot,
+			BlobGasUsed:      executionPayloadHeader.BlobGasUsed,
+			ExcessBlobGas:    executionPayloadHeader.ExcessBlobGas,
+		}
+
 	default:
 		return res, errors.New("unknown fork version")
 
@@ -127,6 +149,25 @@ func (v *VersionedExecutionPayloadHeader) ToBaseExecutionPayloadHeader() (BaseEx
 	res := BaseExecutionPayloadHeader{}
 
 	switch {
+	case v.Electra != nil:
+		res.ParentHash = v.Electra.ParentHash
+		res.FeeRecipient = v.Electra.FeeRecipient
+		res.StateRoot = v.Electra.StateRoot
+		res.ReceiptsRoot = v.Electra.ReceiptsRoot
+		res.LogsBloom = v.Electra.LogsBloom
+		res.PrevRandao = v.Electra.PrevRandao
+		res.BlockNumber = v.Electra.BlockNumber
+		res.GasLimit = v.Electra.GasLimit
+		res.GasUsed = v.Electra.GasUsed
+		res.Timestamp = v.Electra.Timestamp
+		res.ExtraData = v.Electra.ExtraData
+		res.BaseFeePerGas = v.Electra.BaseFeePerGas
+		res.BlockHash = v.Electra.BlockHash
+		res.TransactionsRoot = v.Electra.TransactionsRoot
+		res.WithdrawalsRoot = v.Electra.WithdrawalsRoot
+		res.BlobGasUsed = v.Electra.BlobGasUsed
+		res.ExcessBlobGas = v.Electra.ExcessBlobGas
+
 	case v.Deneb != nil:
 		res.ParentHash = v.Deneb.ParentHash
 		res.FeeRecipient = v.Deneb.FeeRecipient
@@ -197,6 +238,7 @@ func (v *VersionedExecutionPayloadHeader) ToBaseExecutionPayloadHeader() (BaseEx
 		res.ExtraData = v.Bellatrix.ExtraData
 		res.BaseFeePerGas = baseFeePerGas
 		res.BlockHash = v.Bellatrix.BlockHash
+		res.TransactionsRoot = v.Bellatrix.TransactionsRoot
 	default:
 		return res, errors.New("unknown fork version")
 	}
@@ -235,6 +277,8 @@ func (v *VersionedExecutionPayloadHeader) ToVersionedExecutionPayload() (Version
 
 	var forkVersion string
 	switch {
+	case v.Electra != nil:
+		forkVersion = spec.DataVersionElectra.String()
 	case v.Deneb != nil:
 		forkVersion = spec.DataVersionDeneb.String()
 	case v.Capella != nil:
@@ -261,6 +305,8 @@ func (v *VersionedExecutionPayloadHeader) Version() (string, error) {
 		return spec.DataVersionCapella.String(), nil
 	case v.Deneb != nil:
 		return spec.DataVersionDeneb.String(), nil
+	case v.Electra != nil:
+		return spec.DataVersionElectra.String(), nil
 	default:
 		return "", errors.New("no fork version set")
 	}
@@ -274,6 +320,8 @@ func (v *VersionedExecutionPayloadHeader) VersionNumber() (uint64, error) {
 		return uint64(spec.DataVersionCapella), nil
 	case v.Deneb != nil:
 		return uint64(spec.DataVersionDeneb), nil
+	case v.Electra != nil:
+		return uint64(spec.DataVersionElectra), nil
 	default:
 		return 0, errors.New("no fork version set")
 	}
@@ -307,4 +355,4 @@ func (v *VersionedExecutionPayloadHeader) WithVersionName() (VersionedExecutionP
 
 	return res, nil
 
-}
\ No newline at end of file
+}
@@ -10,6 +10,7 @@ import (
 	bellatrix "github.com/attestantio/go-eth2-client/spec/bellatrix"
 	capella "github.com/attestantio/go-eth2-client/spec/capella"
 	deneb "github.com/attestantio/go-eth2-client/spec/deneb"
+	electra "github.com/attestantio/go-eth2-client/spec/electra"
 	phase0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	uint256 "github.com/holiman/uint256"
 )
@@ -35,6 +36,9 @@ type BaseExecutionPayload struct {
 	BlobGasUsed   uint64
 	ExcessBlobGas uint64
 	BlobsBundle   *denebApi.BlobsBundle
+	// Execution requests are carried in the beacon block body from electra
+	// onwards, but are produced alongside the execution payload
+	ExecutionRequests *electra.ExecutionRequests
 }
 
 func ConstructExecutionPayload(
@@ -66,6 +70,7 @@ func ConstructExecutionPayload(
 			Timestamp:     executionPayload.Timestamp,
 			ExtraData:     executionPayload.ExtraData,
 			BaseFeePerGas: baseFeePerGasLE,
+			BlockHash:     executionPayload.BlockHash,
 			Transactions:  executionPayload.Transactions,
 		}
 
@@ -116,6 +121,29 @@ func ConstructExecutionPayload(
 			ExcessBlobGas: executionPayload.ExcessBlobGas,
 		}
 
+	case spec.DataVersionElectra.String():
+		// Electra reuses the deneb execution payload
+		res.Electra = &deneb.ExecutionPayload{
+			ParentHash:    executionPayload.ParentHash,
+			FeeRecipient:  executionPayload.FeeRecipient,
+			StateRoot:     executionPayload.StateRoot,
+			ReceiptsRoot:  executionPayload.ReceiptsRoot,
+			LogsBloom:     executionPayload.LogsBloom,
+			PrevRandao:    executionPayload.PrevRandao,
+			BlockNumber:   executionPayload.BlockNumber,
+			GasLimit:      executionPayload.GasLimit,
+			GasUsed:       executionPayload.GasUsed,
+			Timestamp:     executionPayload.Timestamp,
+			ExtraData:     executionPayload.ExtraData,
+			BaseFeePerGas: executionPayload.BaseFeePerGas,
+			BlockHash:     executionPayload.BlockHash,
+			Transactions:  executionPayload.Transactions,
+			Withdrawals:   executionPayload.Withdrawals,
+			BlobGasUsed:   executionPayload.BlobGasUsed,
+			ExcessBlobGas: executionPayload.ExcessBlobGas,
+		}
+		res.ExecutionRequests = executionPayload.ExecutionRequests
+
 	default:
 		return res, errors.New("unknown fork version")
 
@@ -143,7 +171,13 @@ func ConstructExecutionPayloadV2(
 	if err != nil {
 		return res, err
 	}
-	if v1ForkVersion >= uint64(spec.DataVersionDeneb) {
+	if v1ForkVersion >= uint64(spec.DataVersionElectra) {
+		res.Electra = &denebApi.ExecutionPayloadAndBlobsBundle{
+			ExecutionPayload: v1ExecutionPayload.Electra,
+			BlobsBundle:      executionPayload.BlobsBundle,
+		}
+		res.ExecutionRequests = v1ExecutionPayload.ExecutionRequests
+	} else if v1ForkVersion >= uint64(spec.DataVersionDeneb) {
 		res.Deneb = &denebApi.ExecutionPayloadAndBlobsBundle{
 			ExecutionPayload: v1ExecutionPayload.Deneb,
 			BlobsBundle:      executionPayload.BlobsBundle,
@@ -163,6 +197,26 @@ func (v *VersionedExecutionPayload) ToBaseExecutionPayload() (BaseExecutionPaylo
 	res := BaseExecutionPayload{}
 
 	switch {
+	case v.Electra != nil:
+		res.ParentHash = v.Electra.ParentHash
+		res.FeeRecipient = v.Electra.FeeRecipient
+		res.StateRoot = v.Electra.StateRoot
+		res.ReceiptsRoot = v.Electra.ReceiptsRoot
+		res.LogsBloom = v.Electra.LogsBloom
+		res.PrevRandao = v.Electra.PrevRandao
+		res.BlockNumber = v.Electra.BlockNumber
+		res.GasLimit = v.Electra.GasLimit
+		res.GasUsed = v.Electra.GasUsed
+		res.Timestamp = v.Electra.Timestamp
+		res.ExtraData = v.Electra.ExtraData
+		res.BaseFeePerGas = v.Electra.BaseFeePerGas
+		res.BlockHash = v.Electra.BlockHash
+		res.Transactions = v.Electra.Transactions
+		res.Withdrawals = v.Electra.Withdrawals
+		res.BlobGasUsed = v.Electra.BlobGasUsed
+		res.ExcessBlobGas = v.Electra.ExcessBlobGas
+		res.ExecutionRequests = v.ExecutionRequests
+
 	case v.Deneb != nil:
 		res.ParentHash = v.Deneb.ParentHash
 		res.FeeRecipient = v.Deneb.FeeRecipient
@@ -247,6 +301,12 @@ func (v *VersionedExecutionPayload) ToVersionedExecutionPayloadV2() (VersionedEx
 	res := VersionedExecutionPayloadV2{}
 
 	switch {
+	case v.Electra != nil:
+		res.Electra = &denebApi.ExecutionPayloadAndBlobsBundle{
+			ExecutionPayload: v.Electra,
+			BlobsBundle:      &denebApi.BlobsBundle{}, // Cannot obtain blobs data from v1
+		}
+		res.ExecutionRequests = v.ExecutionRequests
 	case v.Deneb != nil:
 		res.Deneb = &denebApi.ExecutionPayloadAndBlobsBundle{
 			ExecutionPayload: v.Deneb,
@@ -269,6 +329,9 @@ func (v *VersionedExecutionPayloadV2) ToVersionedExecutionPayload() (VersionedEx
 	res := VersionedExecutionPayload{}
 
 	switch {
+	case v.Electra != nil:
+		res.Electra = v.Electra.ExecutionPayload
+		res.ExecutionRequests = v.ExecutionRequests
 	case v.Deneb != nil:
 		res.Deneb = v.Deneb.ExecutionPayload
 	case v.Capella != nil:
@@ -301,7 +364,9 @@ func (v *VersionedExecutionPayloadV2) ToBaseExecutionPayload() (BaseExecutionPay
 	if err != nil {
 		return res, err
 	}
-	if vNum >= uint64(spec.DataVersionDeneb) {
+	if vNum >= uint64(spec.DataVersionElectra) {
+		res.BlobsBundle = v.Electra.BlobsBundle
+	} else if vNum >= uint64(spec.DataVersionDeneb) {
 		res.BlobsBundle = v.Deneb.BlobsBundle
 	}
 
@@ -349,6 +414,8 @@ func (v *VersionedExecutionPayload) ToVersionedExecutionPayloadHeader() (Version
 
 	var forkVersion string
 	switch {
+	case v.Electra != nil:
+		forkVersion = spec.DataVersionElectra.String()
 	case v.Deneb != nil:
 		forkVersion = spec.DataVersionDeneb.String()
 	case v.Capella != nil:
@@ -391,6 +458,8 @@ func (v *VersionedExecutionPayload) Version() (string, error) {
 		return spec.DataVersionCapella.String(), nil
 	case v.Deneb != nil:
 		return spec.DataVersionDeneb.String(), nil
+	case v.Electra != nil:
+		return spec.DataVersionElectra.String(), nil
 	default:
 		return "", errors.New("no fork version set")
 	}
@@ -404,6 +473,8 @@ func (v *VersionedExecutionPayloadV2) Version() (string, error) {
 		return spec.DataVersionCapella.String(), nil
 	case v.Deneb != nil:
 		return spec.DataVersionDeneb.String(), nil
+	case v.Electra != nil:
+		return spec.DataVersionElectra.String(), nil
 	default:
 		return "", errors.New("no fork version set")
 	}
@@ -417,6 +488,8 @@ func (v *VersionedExecutionPayload) VersionNumber() (uint64, error) {
 		return uint64(spec.DataVersionCapella), nil
 	case v.Deneb != nil:
 		return uint64(spec.DataVersionDeneb), nil
+	case v.Electra != nil:
+		return uint64(spec.DataVersionElectra), nil
 	default:
 		return 0, errors.New("no fork version set")
 	}
@@ -430,6 +503,8 @@ func (v *VersionedExecutionPayloadV2) VersionNumber() (uint64, error) {
 		return uint64(spec.DataVersionCapella), nil
 	case v.Deneb != nil:
 		return uint64(spec.DataVersionDeneb), nil
+	case v.Electra != nil:
+		return uint64(spec.DataVersionElectra), nil
 	default:
 		return 0, errors.New("no fork version set")
 	}
@@ -0,0 +1,116 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	bellatrix "github.com/attestantio/go-eth2-client/spec/bellatrix"
+	capella "github.com/attestantio/go-eth2-client/spec/capella"
+	electra "github.com/attestantio/go-eth2-client/spec/electra"
+	phase0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	uint256 "github.com/holiman/uint256"
+)
+
+func testExecutionRequests() *electra.ExecutionRequests {
+	return &electra.ExecutionRequests{
+		Deposits: []*electra.DepositRequest{{
+			Pubkey:                phase0.BLSPubKey{0x01},
+			WithdrawalCredentials: make([]byte, 32),
+			Amount:                32000000000,
+			Signature:             phase0.BLSSignature{0x02},
+			Index:                 7,
+		}},
+		Withdrawals: []*electra.WithdrawalRequest{{
+			SourceAddress:   bellatrix.ExecutionAddress{0x03},
+			ValidatorPubkey: phase0.BLSPubKey{0x04},
+			Amount:          1,
+		}},
+		Consolidations: []*electra.ConsolidationRequest{},
+	}
+}
+
+func testBaseExecutionPayload() BaseExecutionPayload {
+	return BaseExecutionPayload{
+		ParentHash:    phase0.Hash32{0x01},
+		BlockHash:     phase0.Hash32{0x02},
+		BlockNumber:   100,
+		GasLimit:      30000000,
+		Timestamp:     1700000000,
+		ExtraData:     []byte{},
+		BaseFeePerGas: uint256.NewInt(7),
+		Transactions:  []bellatrix.Transaction{},
+		Withdrawals:   []*capella.Withdrawal{},
+	}
+}
+
+func TestElectraPayloadKeepsExecutionRequests(t *testing.T) {
+	base := testBaseExecutionPayload()
+	base.ExecutionRequests = testExecutionRequests()
+
+	payload, err := ConstructExecutionPayload(spec.DataVersionElectra.String(), base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payload.ExecutionRequests != base.ExecutionRequests {
+		t.Fatal("execution requests not set on the versioned payload")
+	}
+
+	res, err := payload.ToBaseExecutionPayload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ExecutionRequests == nil {
+		t.Fatal("execution requests lost converting to base payload")
+	}
+	expected, _ := base.ExecutionRequests.HashTreeRoot()
+	actual, _ := res.ExecutionRequests.HashTreeRoot()
+	if expected != actual {
+		t.Fatal("execution requests changed converting to base payload")
+	}
+}
+
+func TestElectraPayloadV2KeepsExecutionRequests(t *testing.T) {
+	base := testBaseExecutionPayload()
+	base.ExecutionRequests = testExecutionRequests()
+
+	payload, err := ConstructExecutionPayloadV2(spec.DataVersionElectra.String(), base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := payload.ToBaseExecutionPayload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ExecutionRequests != base.ExecutionRequests {
+		t.Fatal("execution requests lost converting v2 payload to base payload")
+	}
+
+	v1, err := payload.ToVersionedExecutionPayload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	v2, err := v1.ToVersionedExecutionPayloadV2()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v2.ExecutionRequests != base.ExecutionRequests {
+		t.Fatal("execution requests lost converting between payload versions")
+	}
+}
+
+func TestDenebPayloadHasNoExecutionRequests(t *testing.T) {
+	base := testBaseExecutionPayload()
+	base.ExecutionRequests = testExecutionRequests()
+
+	payload, err := ConstructExecutionPayload(spec.DataVersionDeneb.String(), base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := payload.ToBaseExecutionPayload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ExecutionRequests != nil {
+		t.Fatal("deneb payload should not carry execution requests")
+	}
+}
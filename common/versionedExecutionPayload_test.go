@@ -0,0 +1,351 @@
+package common
+
+import (
+	"encoding/json"
+	"testing"
+
+	denebApi "github.com/attestantio/go-builder-client/api/deneb"
+	"github.com/attestantio/go-eth2-client/spec"
+	deneb "github.com/attestantio/go-eth2-client/spec/deneb"
+	electra "github.com/attestantio/go-eth2-client/spec/electra"
+)
+
+func testElectraPayload(t *testing.T) *VersionedExecutionPayload {
+	t.Helper()
+	base := testBaseExecutionPayload()
+	base.ExecutionRequests = testExecutionRequests()
+	payload, err := ConstructExecutionPayload(spec.DataVersionElectra.String(), base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &payload
+}
+
+func testCheckExecutionRequests(t *testing.T, expected, actual *electra.ExecutionRequests) {
+	t.Helper()
+	if actual == nil {
+		t.Fatal("execution requests lost")
+	}
+	expectedRoot, err := expected.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	actualRoot, err := actual.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expectedRoot != actualRoot {
+		t.Fatalf("expected execution requests root %#x, got %#x", expectedRoot, actualRoot)
+	}
+}
+
+func testDenebPayloadAndBlobsBundle(t *testing.T) *denebApi.ExecutionPayloadAndBlobsBundle {
+	t.Helper()
+	payload, err := ConstructExecutionPayload(spec.DataVersionDeneb.String(), testBaseExecutionPayload())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &denebApi.ExecutionPayloadAndBlobsBundle{
+		ExecutionPayload: payload.Deneb,
+		BlobsBundle: &denebApi.BlobsBundle{
+			Commitments: []deneb.KZGCommitment{{0x01}},
+			Proofs:      []deneb.KZGProof{{0x02}},
+			Blobs:       []deneb.Blob{{0x03}},
+		},
+	}
+}
+
+func TestVersionedExecutionPayloadV2UnmarshalJSONWithVersion(t *testing.T) {
+	bundle := testDenebPayloadAndBlobsBundle(t)
+	bundleJSON, err := bundle.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	payloadJSON, err := bundle.ExecutionPayload.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := VersionedExecutionPayloadV2{}
+	if err := res.UnmarshalJSONWithVersion(spec.DataVersionDeneb, bundleJSON); err != nil {
+		t.Fatal(err)
+	}
+	if res.Deneb == nil || len(res.Deneb.BlobsBundle.Blobs) != 1 {
+		t.Fatal("payload decoded into the wrong fork or without blobs")
+	}
+
+	// A payload without its blobs bundle must not fall back to a v1 payload, and an electra
+	// payload must come with its execution requests
+	for version, input := range map[spec.DataVersion][]byte{
+		spec.DataVersionDeneb:   payloadJSON,
+		spec.DataVersionElectra: bundleJSON,
+	} {
+		res = VersionedExecutionPayloadV2{}
+		if err := res.UnmarshalJSONWithVersion(version, input); err == nil {
+			t.Fatalf("%s: incomplete payload accepted", version)
+		}
+	}
+}
+
+func TestVersionedExecutionPayloadV2UnmarshalSSZWithVersion(t *testing.T) {
+	bundle := testDenebPayloadAndBlobsBundle(t)
+	bundleSSZ, err := bundle.MarshalSSZ()
+	if err != nil {
+		t.Fatal(err)
+	}
+	payloadSSZ, err := bundle.ExecutionPayload.MarshalSSZ()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := VersionedExecutionPayloadV2{}
+	if err := res.UnmarshalSSZWithVersion(spec.DataVersionDeneb, bundleSSZ); err != nil {
+		t.Fatal(err)
+	}
+	if res.Deneb == nil || res.Electra != nil {
+		t.Fatal("payload not decoded as deneb")
+	}
+
+	res = VersionedExecutionPayloadV2{}
+	if err := res.UnmarshalSSZWithVersion(spec.DataVersionDeneb, payloadSSZ); err == nil {
+		t.Fatal("payload without blobs bundle accepted")
+	}
+	res = VersionedExecutionPayloadV2{}
+	if err := res.UnmarshalSSZWithVersion(spec.DataVersionElectra, bundleSSZ); err == nil {
+		t.Fatal("electra payload without execution requests accepted")
+	}
+}
+
+func TestVersionedExecutionPayloadV2UnmarshalJSONWithVersionCapella(t *testing.T) {
+	payload, err := ConstructExecutionPayload(spec.DataVersionCapella.String(), testBaseExecutionPayload())
+	if err != nil {
+		t.Fatal(err)
+	}
+	payloadJSON, err := payload.Capella.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := VersionedExecutionPayloadV2{}
+	if err := res.UnmarshalJSONWithVersion(spec.DataVersionCapella, payloadJSON); err != nil {
+		t.Fatal(err)
+	}
+	if res.Capella == nil {
+		t.Fatal("payload not decoded as capella")
+	}
+}
+
+func TestVersionedExecutionPayloadElectraJSON(t *testing.T) {
+	payload := testElectraPayload(t)
+	input, err := payload.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	withVersion := VersionedExecutionPayload{}
+	if err := withVersion.UnmarshalJSONWithVersion(spec.DataVersionElectra, input); err != nil {
+		t.Fatal(err)
+	}
+	// Without a version the execution requests tell the payload apart from a deneb payload
+	withoutVersion := VersionedExecutionPayload{}
+	if err := withoutVersion.UnmarshalJSON(input); err != nil {
+		t.Fatal(err)
+	}
+	inputYAML, err := payload.MarshalYAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromYAML := VersionedExecutionPayload{}
+	if err := fromYAML.UnmarshalYAML(inputYAML); err != nil {
+		t.Fatal(err)
+	}
+	for _, res := range []VersionedExecutionPayload{withVersion, withoutVersion, fromYAML} {
+		if res.Electra == nil || res.Deneb != nil {
+			t.Fatal("payload not decoded as electra")
+		}
+		testCheckExecutionRequests(t, payload.ExecutionRequests, res.ExecutionRequests)
+		if _, err := res.ToBaseExecutionPayload(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	envelope, err := json.Marshal(&VersionedExecutionPayloadWithVersionName{
+		VersionName:               "electra",
+		VersionedExecutionPayload: payload,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := VersionedExecutionPayloadWithVersionName{}
+	if err := json.Unmarshal(envelope, &res); err != nil {
+		t.Fatal(err)
+	}
+	testCheckExecutionRequests(t, payload.ExecutionRequests, res.VersionedExecutionPayload.ExecutionRequests)
+
+	// An electra payload cannot be encoded or decoded without its execution requests
+	if _, err := (&VersionedExecutionPayload{Electra: payload.Electra}).MarshalJSON(); err == nil {
+		t.Fatal("electra payload without execution requests encoded")
+	}
+	payloadJSON, err := payload.Electra.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := withVersion.UnmarshalJSONWithVersion(spec.DataVersionElectra, payloadJSON); err == nil {
+		t.Fatal("electra payload without execution requests decoded")
+	}
+}
+
+func TestVersionedExecutionPayloadElectraSSZ(t *testing.T) {
+	payload := testElectraPayload(t)
+	input, err := payload.MarshalSSZ()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(input) != payload.SizeSSZ() {
+		t.Fatalf("expected %d bytes, got %d", payload.SizeSSZ(), len(input))
+	}
+
+	withVersion := VersionedExecutionPayload{}
+	if err := withVersion.UnmarshalSSZWithVersion(spec.DataVersionElectra, input); err != nil {
+		t.Fatal(err)
+	}
+	withoutVersion := VersionedExecutionPayload{}
+	if err := withoutVersion.UnmarshalSSZ(input); err != nil {
+		t.Fatal(err)
+	}
+	for _, res := range []VersionedExecutionPayload{withVersion, withoutVersion} {
+		if res.Electra == nil || res.Deneb != nil {
+			t.Fatal("payload not decoded as electra")
+		}
+		testCheckExecutionRequests(t, payload.ExecutionRequests, res.ExecutionRequests)
+		root, err := res.HashTreeRoot()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected, _ := payload.Electra.HashTreeRoot(); root != expected {
+			t.Fatalf("expected payload root %#x, got %#x", expected, root)
+		}
+	}
+
+	// A deneb payload is still decoded as deneb without a version
+	denebPayload, err := ConstructExecutionPayload(spec.DataVersionDeneb.String(), testBaseExecutionPayload())
+	if err != nil {
+		t.Fatal(err)
+	}
+	denebSSZ, err := denebPayload.MarshalSSZ()
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := VersionedExecutionPayload{}
+	if err := res.UnmarshalSSZ(denebSSZ); err != nil {
+		t.Fatal(err)
+	}
+	if res.Deneb == nil || res.Electra != nil {
+		t.Fatal("payload not decoded as deneb")
+	}
+}
+
+func TestVersionedExecutionPayloadV2Electra(t *testing.T) {
+	payload, err := testElectraPayload(t).ToVersionedExecutionPayloadV2()
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload.Electra.BlobsBundle = testDenebPayloadAndBlobsBundle(t).BlobsBundle
+
+	inputJSON, err := payload.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputSSZ, err := payload.MarshalSSZ()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inputSSZ) != payload.SizeSSZ() {
+		t.Fatalf("expected %d bytes, got %d", payload.SizeSSZ(), len(inputSSZ))
+	}
+
+	decoders := map[string]func(res *VersionedExecutionPayloadV2) error{
+		"json with version": func(res *VersionedExecutionPayloadV2) error {
+			return res.UnmarshalJSONWithVersion(spec.DataVersionElectra, inputJSON)
+		},
+		"json": func(res *VersionedExecutionPayloadV2) error { return res.UnmarshalJSON(inputJSON) },
+		"ssz with version": func(res *VersionedExecutionPayloadV2) error {
+			return res.UnmarshalSSZWithVersion(spec.DataVersionElectra, inputSSZ)
+		},
+		"ssz": func(res *VersionedExecutionPayloadV2) error { return res.UnmarshalSSZ(inputSSZ) },
+	}
+	for name, decode := range decoders {
+		res := VersionedExecutionPayloadV2{}
+		if err := decode(&res); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if res.Electra == nil || res.Deneb != nil || len(res.Electra.BlobsBundle.Blobs) != 1 {
+			t.Fatalf("%s: payload not decoded as electra with its blobs", name)
+		}
+		testCheckExecutionRequests(t, payload.ExecutionRequests, res.ExecutionRequests)
+	}
+
+	envelope, err := json.Marshal(&VersionedExecutionPayloadV2WithVersionName{
+		VersionName:               "electra",
+		VersionedExecutionPayload: &payload,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := VersionedExecutionPayloadV2WithVersionName{}
+	if err := json.Unmarshal(envelope, &res); err != nil {
+		t.Fatal(err)
+	}
+	testCheckExecutionRequests(t, payload.ExecutionRequests, res.VersionedExecutionPayload.ExecutionRequests)
+}
+
+func TestVersionedExecutionPayloadV2RequiresBlobsBundle(t *testing.T) {
+	bundle := testDenebPayloadAndBlobsBundle(t)
+	payloadJSON, err := bundle.ExecutionPayload.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	payloadSSZ, err := bundle.ExecutionPayload.MarshalSSZ()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Decoding without a version applies the same rule as decoding with a version
+	res := VersionedExecutionPayloadV2{}
+	if err := res.UnmarshalJSON(payloadJSON); err == nil {
+		t.Fatal("json payload without blobs bundle accepted")
+	}
+	res = VersionedExecutionPayloadV2{}
+	if err := res.UnmarshalSSZ(payloadSSZ); err == nil {
+		t.Fatal("ssz payload without blobs bundle accepted")
+	}
+
+	bundleJSON, err := bundle.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	res = VersionedExecutionPayloadV2{}
+	if err := res.UnmarshalJSON(bundleJSON); err != nil {
+		t.Fatal(err)
+	}
+	if res.Deneb == nil || len(res.Deneb.BlobsBundle.Blobs) != 1 {
+		t.Fatal("payload not decoded as deneb with its blobs")
+	}
+
+	// Versions before deneb have no blobs bundle
+	capellaPayload, err := ConstructExecutionPayload(spec.DataVersionCapella.String(), testBaseExecutionPayload())
+	if err != nil {
+		t.Fatal(err)
+	}
+	capellaJSON, err := capellaPayload.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	res = VersionedExecutionPayloadV2{}
+	if err := res.UnmarshalJSON(capellaJSON); err != nil {
+		t.Fatal(err)
+	}
+	if res.Capella == nil {
+		t.Fatal("payload not decoded as capella")
+	}
+}
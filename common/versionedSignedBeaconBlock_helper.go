@@ -9,6 +9,7 @@ import (
 	bellatrix "github.com/attestantio/go-eth2-client/spec/bellatrix"
 	capella "github.com/attestantio/go-eth2-client/spec/capella"
 	deneb "github.com/attestantio/go-eth2-client/spec/deneb"
+	electra "github.com/attestantio/go-eth2-client/spec/electra"
 	phase0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	uint256 "github.com/holiman/uint256"
 )
@@ -48,6 +49,11 @@ func ConstructSignedBeaconBlock(
 			Message:   VersionedBeaconBlock.Deneb,
 			Signature: signedBeaconBlock.Signature,
 		}
+	case spec.DataVersionElectra.String():
+		res.Electra = &electra.SignedBeaconBlock{
+			Message:   VersionedBeaconBlock.Electra,
+			Signature: signedBeaconBlock.Signature,
+		}
 	default:
 		return res, errors.New("unsupported fork version")
 	}
@@ -193,6 +199,49 @@ func (b *VersionedSignedBeaconBlock) ToBaseSignedBeaconBlock() (BaseSignedBeacon
 			},
 		}
 		res.Signature = b.Deneb.Signature
+	case b.Electra != nil:
+
+		res.Message = &BaseBeaconBlock{
+			Slot:          b.Electra.Message.Slot,
+			ProposerIndex: b.Electra.Message.ProposerIndex,
+			ParentRoot:    b.Electra.Message.ParentRoot,
+			StateRoot:     b.Electra.Message.StateRoot,
+			Body: &BaseBeaconBlockBody{
+				RANDAOReveal:             b.Electra.Message.Body.RANDAOReveal,
+				ETH1Data:                 b.Electra.Message.Body.ETH1Data,
+				Graffiti:                 b.Electra.Message.Body.Graffiti,
+				ProposerSlashings:        b.Electra.Message.Body.ProposerSlashings,
+				AttesterSlashingsElectra: b.Electra.Message.Body.AttesterSlashings,
+				AttestationsElectra:      b.Electra.Message.Body.Attestations,
+				Deposits:                 b.Electra.Message.Body.Deposits,
+				VoluntaryExits:           b.Electra.Message.Body.VoluntaryExits,
+				SyncAggregate:            b.Electra.Message.Body.SyncAggregate,
+				ExecutionPayload: &BaseExecutionPayload{
+					ParentHash:        b.Electra.Message.Body.ExecutionPayload.ParentHash,
+					FeeRecipient:      b.Electra.Message.Body.ExecutionPayload.FeeRecipient,
+					StateRoot:         b.Electra.Message.Body.ExecutionPayload.StateRoot,
+					ReceiptsRoot:      b.Electra.Message.Body.ExecutionPayload.ReceiptsRoot,
+					LogsBloom:         b.Electra.Message.Body.ExecutionPayload.LogsBloom,
+					PrevRandao:        b.Electra.Message.Body.ExecutionPayload.PrevRandao,
+					BlockNumber:       b.Electra.Message.Body.ExecutionPayload.BlockNumber,
+					GasLimit:          b.Electra.Message.Body.ExecutionPayload.GasLimit,
+					GasUsed:           b.Electra.Message.Body.ExecutionPayload.GasUsed,
+					Timestamp:         b.Electra.Message.Body.ExecutionPayload.Timestamp,
+					ExtraData:         b.Electra.Message.Body.ExecutionPayload.ExtraData,
+					BaseFeePerGas:     b.Electra.Message.Body.ExecutionPayload.BaseFeePerGas,
+					BlockHash:         b.Electra.Message.Body.ExecutionPayload.BlockHash,
+					Transactions:      b.Electra.Message.Body.ExecutionPayload.Transactions,
+					Withdrawals:       b.Electra.Message.Body.ExecutionPayload.Withdrawals,
+					BlobGasUsed:       b.Electra.Message.Body.ExecutionPayload.BlobGasUsed,
+					ExcessBlobGas:     b.Electra.Message.Body.ExecutionPayload.ExcessBlobGas,
+					ExecutionRequests: b.Electra.Message.Body.ExecutionRequests,
+				},
+				BLSToExecutionChanges: b.Electra.Message.Body.BLSToExecutionChanges,
+				BlobKZGCommitments:    b.Electra.Message.Body.BlobKZGCommitments,
+				ExecutionRequests:     b.Electra.Message.Body.ExecutionRequests,
+			},
+		}
+		res.Signature = b.Electra.Signature
 	default:
 		return res, errors.New("unsupported fork version")
 	}
@@ -208,6 +257,8 @@ func (b *VersionedSignedBeaconBlock) Version() (string, error) {
 		return spec.DataVersionCapella.String(), nil
 	case b.Deneb != nil:
 		return spec.DataVersionDeneb.String(), nil
+	case b.Electra != nil:
+		return spec.DataVersionElectra.String(), nil
 	default:
 		return "", errors.New("no fork version set")
 	}
@@ -221,6 +272,8 @@ func (b *VersionedSignedBeaconBlock) VersionNumber() (uint64, error) {
 		return uint64(spec.DataVersionCapella), nil
 	case b.Deneb != nil:
 		return uint64(spec.DataVersionDeneb), nil
+	case b.Electra != nil:
+		return uint64(spec.DataVersionElectra), nil
 	default:
 		return 0, errors.New("no fork version set")
 	}
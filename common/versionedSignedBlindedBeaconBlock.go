@@ -1,19 +1,25 @@
 package common
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 
 	ssz "github.com/ferranbt/fastssz"
 
+	"github.com/attestantio/go-eth2-client/spec"
+
 	bellatrix "github.com/attestantio/go-eth2-client/api/v1/bellatrix"
 	capella "github.com/attestantio/go-eth2-client/api/v1/capella"
 	deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	electra "github.com/attestantio/go-eth2-client/api/v1/electra"
 )
 
 type VersionedSignedBlindedBeaconBlock struct {
 	Bellatrix *bellatrix.SignedBlindedBeaconBlock `json:"bellatrix,omitempty"`
 	Capella   *capella.SignedBlindedBeaconBlock   `json:"capella,omitempty"`
 	Deneb     *deneb.SignedBlindedBeaconBlock     `json:"deneb,omitempty"`
+	Electra   *electra.SignedBlindedBeaconBlock   `json:"electra,omitempty"`
 }
 
 type VersionedSignedBlindedBeaconBlockWithVersionNumber struct {
@@ -27,6 +33,9 @@ type VersionedSignedBlindedBeaconBlockWithVersionName struct {
 }
 
 func (v *VersionedSignedBlindedBeaconBlock) GetTree() (*ssz.Node, error) {
+	if v.Electra != nil {
+		return v.Electra.GetTree()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.GetTree()
 	}
@@ -40,6 +49,9 @@ func (v *VersionedSignedBlindedBeaconBlock) GetTree() (*ssz.Node, error) {
 }
 
 func (v *VersionedSignedBlindedBeaconBlock) HashTreeRoot() ([32]byte, error) {
+	if v.Electra != nil {
+		return v.Electra.HashTreeRoot()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.HashTreeRoot()
 	}
@@ -53,6 +65,9 @@ func (v *VersionedSignedBlindedBeaconBlock) HashTreeRoot() ([32]byte, error) {
 }
 
 func (v *VersionedSignedBlindedBeaconBlock) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	if v.Electra != nil {
+		return v.Electra.HashTreeRootWith(hh)
+	}
 	if v.Deneb != nil {
 		return v.Deneb.HashTreeRootWith(hh)
 	}
@@ -66,6 +81,9 @@ func (v *VersionedSignedBlindedBeaconBlock) HashTreeRootWith(hh ssz.HashWalker)
 }
 
 func (v *VersionedSignedBlindedBeaconBlock) MarshalJSON() ([]byte, error) {
+	if v.Electra != nil {
+		return v.Electra.MarshalJSON()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.MarshalJSON()
 	}
@@ -79,6 +97,9 @@ func (v *VersionedSignedBlindedBeaconBlock) MarshalJSON() ([]byte, error) {
 }
 
 func (v *VersionedSignedBlindedBeaconBlock) MarshalSSZ() ([]byte, error) {
+	if v.Electra != nil {
+		return v.Electra.MarshalSSZ()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.MarshalSSZ()
 	}
@@ -92,6 +113,9 @@ func (v *VersionedSignedBlindedBeaconBlock) MarshalSSZ() ([]byte, error) {
 }
 
 func (v *VersionedSignedBlindedBeaconBlock) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if v.Electra != nil {
+		return v.Electra.MarshalSSZTo(buf)
+	}
 	if v.Deneb != nil {
 		return v.Deneb.MarshalSSZTo(buf)
 	}
@@ -105,6 +129,9 @@ func (v *VersionedSignedBlindedBeaconBlock) MarshalSSZTo(buf []byte) (dst []byte
 }
 
 func (v *VersionedSignedBlindedBeaconBlock) MarshalYAML() ([]byte, error) {
+	if v.Electra != nil {
+		return v.Electra.MarshalYAML()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.MarshalYAML()
 	}
@@ -118,6 +145,9 @@ func (v *VersionedSignedBlindedBeaconBlock) MarshalYAML() ([]byte, error) {
 }
 
 func (v *VersionedSignedBlindedBeaconBlock) SizeSSZ() (size int) {
+	if v.Electra != nil {
+		return v.Electra.SizeSSZ()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.SizeSSZ()
 	}
@@ -131,6 +161,9 @@ func (v *VersionedSignedBlindedBeaconBlock) SizeSSZ() (size int) {
 }
 
 func (v *VersionedSignedBlindedBeaconBlock) String() string {
+	if v.Electra != nil {
+		return v.Electra.String()
+	}
 	if v.Deneb != nil {
 		return v.Deneb.String()
 	}
@@ -149,9 +182,17 @@ func (v *VersionedSignedBlindedBeaconBlock) UnmarshalJSON(input []byte) error {
 
 	// Type is forkversion naive so we need to try each type in reverse
 	// fork version order.  This is because the fork version is not
-	// included in the JSON data.
+	// included in the JSON data. Prefer UnmarshalJSONWithVersion when
+	// the fork version is known.
 	var err error
 
+	v.Electra = &electra.SignedBlindedBeaconBlock{}
+	err = v.Electra.UnmarshalJSON(input)
+	if err == nil {
+		return nil
+	}
+	v.Electra = nil
+
 	v.Deneb = &deneb.SignedBlindedBeaconBlock{}
 	err = v.Deneb.UnmarshalJSON(input)
 	if err == nil {
@@ -183,9 +224,17 @@ func (v *VersionedSignedBlindedBeaconBlock) UnmarshalSSZ(buf []byte) error {
 
 	// Type is forkversion naive so we need to try each type in reverse
 	// fork version order.  This is because the fork version is not
-	// included in the SSZ data.
+	// included in the SSZ data. Prefer UnmarshalSSZWithVersion when
+	// the fork version is known.
 	var err error
 
+	v.Electra = &electra.SignedBlindedBeaconBlock{}
+	err = v.Electra.UnmarshalSSZ(buf)
+	if err == nil {
+		return nil
+	}
+	v.Electra = nil
+
 	v.Deneb = &deneb.SignedBlindedBeaconBlock{}
 	err = v.Deneb.UnmarshalSSZ(buf)
 	if err == nil {
@@ -220,6 +269,13 @@ func (v *VersionedSignedBlindedBeaconBlock) UnmarshalYAML(input []byte) error {
 	// included in the YAML data.
 	var err error
 
+	v.Electra = &electra.SignedBlindedBeaconBlock{}
+	err = v.Electra.UnmarshalYAML(input)
+	if err == nil {
+		return nil
+	}
+	v.Electra = nil
+
 	v.Deneb = &deneb.SignedBlindedBeaconBlock{}
 	err = v.Deneb.UnmarshalYAML(input)
 	if err == nil {
@@ -244,3 +300,128 @@ func (v *VersionedSignedBlindedBeaconBlock) UnmarshalYAML(input []byte) error {
 	return errors.New("unsupported SignedBlindedBeaconBlock type")
 
 }
+
+// UnmarshalJSONWithVersion decodes JSON data as the given fork version instead of
+// trying each fork version in turn, as the version is known by the caller
+func (v *VersionedSignedBlindedBeaconBlock) UnmarshalJSONWithVersion(version spec.DataVersion, input []byte) error {
+	res := VersionedSignedBlindedBeaconBlock{}
+
+	switch version {
+	case spec.DataVersionBellatrix:
+		res.Bellatrix = &bellatrix.SignedBlindedBeaconBlock{}
+		if err := res.Bellatrix.UnmarshalJSON(input); err != nil {
+			return err
+		}
+	case spec.DataVersionCapella:
+		res.Capella = &capella.SignedBlindedBeaconBlock{}
+		if err := res.Capella.UnmarshalJSON(input); err != nil {
+			return err
+		}
+	case spec.DataVersionDeneb:
+		res.Deneb = &deneb.SignedBlindedBeaconBlock{}
+		if err := res.Deneb.UnmarshalJSON(input); err != nil {
+			return err
+		}
+	case spec.DataVersionElectra:
+		res.Electra = &electra.SignedBlindedBeaconBlock{}
+		if err := res.Electra.UnmarshalJSON(input); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported SignedBlindedBeaconBlock version %s", version)
+	}
+
+	*v = res
+	return nil
+}
+
+// UnmarshalSSZWithVersion decodes SSZ data as the given fork version instead of
+// trying each fork version in turn, as the version is known by the caller
+func (v *VersionedSignedBlindedBeaconBlock) UnmarshalSSZWithVersion(version spec.DataVersion, buf []byte) error {
+	res := VersionedSignedBlindedBeaconBlock{}
+
+	switch version {
+	case spec.DataVersionBellatrix:
+		res.Bellatrix = &bellatrix.SignedBlindedBeaconBlock{}
+		if err := res.Bellatrix.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	case spec.DataVersionCapella:
+		res.Capella = &capella.SignedBlindedBeaconBlock{}
+		if err := res.Capella.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	case spec.DataVersionDeneb:
+		res.Deneb = &deneb.SignedBlindedBeaconBlock{}
+		if err := res.Deneb.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	case spec.DataVersionElectra:
+		res.Electra = &electra.SignedBlindedBeaconBlock{}
+		if err := res.Electra.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported SignedBlindedBeaconBlock version %s", version)
+	}
+
+	*v = res
+	return nil
+}
+
+// UnmarshalJSON decodes the data using the declared version
+func (v *VersionedSignedBlindedBeaconBlockWithVersionNumber) UnmarshalJSON(input []byte) error {
+	var data struct {
+		VersionNumber uint64          `json:"version,string"`
+		Data          json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(input, &data); err != nil {
+		return err
+	}
+
+	v.VersionNumber = data.VersionNumber
+	v.VersionedSignedBlindedBeaconBlock = nil
+	if len(data.Data) == 0 || string(data.Data) == "null" {
+		return nil
+	}
+
+	version := spec.DataVersion(data.VersionNumber)
+
+	res := &VersionedSignedBlindedBeaconBlock{}
+	if err := res.UnmarshalJSONWithVersion(version, data.Data); err != nil {
+		return err
+	}
+	v.VersionedSignedBlindedBeaconBlock = res
+
+	return nil
+}
+
+// UnmarshalJSON decodes the data using the declared version
+func (v *VersionedSignedBlindedBeaconBlockWithVersionName) UnmarshalJSON(input []byte) error {
+	var data struct {
+		VersionName string          `json:"version"`
+		Data        json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(input, &data); err != nil {
+		return err
+	}
+
+	v.VersionName = data.VersionName
+	v.VersionedSignedBlindedBeaconBlock = nil
+	if len(data.Data) == 0 || string(data.Data) == "null" {
+		return nil
+	}
+
+	version, err := DataVersionFromName(data.VersionName)
+	if err != nil {
+		return err
+	}
+
+	res := &VersionedSignedBlindedBeaconBlock{}
+	if err := res.UnmarshalJSONWithVersion(version, data.Data); err != nil {
+		return err
+	}
+	v.VersionedSignedBlindedBeaconBlock = res
+
+	return nil
+}
@@ -2,13 +2,17 @@ package common
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/attestantio/go-eth2-client/spec"
 
+	builderDenebApi "github.com/attestantio/go-builder-client/api/deneb"
+
 	bellatrix "github.com/attestantio/go-eth2-client/api/v1/bellatrix"
 	capella "github.com/attestantio/go-eth2-client/api/v1/capella"
 	deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	electra "github.com/attestantio/go-eth2-client/api/v1/electra"
 	phase0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	uint256 "github.com/holiman/uint256"
 )
@@ -48,6 +52,11 @@ func ConstructSignedBlindedBeaconBlock(
 			Message:   VersionedBlindedBeaconBlock.Deneb,
 			Signature: signedBlindedBeaconBlock.Signature,
 		}
+	case spec.DataVersionElectra.String():
+		res.Electra = &electra.SignedBlindedBeaconBlock{
+			Message:   VersionedBlindedBeaconBlock.Electra,
+			Signature: signedBlindedBeaconBlock.Signature,
+		}
 	default:
 		return res, errors.New("unsupported fork version")
 	}
@@ -193,6 +202,48 @@ func (b *VersionedSignedBlindedBeaconBlock) ToBaseSignedBlindedBeaconBlock() (Ba
 			},
 		}
 		res.Signature = b.Deneb.Signature
+	case b.Electra != nil:
+
+		res.Message = &BaseBlindedBeaconBlock{
+			Slot:          b.Electra.Message.Slot,
+			ProposerIndex: b.Electra.Message.ProposerIndex,
+			ParentRoot:    b.Electra.Message.ParentRoot,
+			StateRoot:     b.Electra.Message.StateRoot,
+			Body: &BaseBlindedBeaconBlockBody{
+				RANDAOReveal:             b.Electra.Message.Body.RANDAOReveal,
+				ETH1Data:                 b.Electra.Message.Body.ETH1Data,
+				Graffiti:                 b.Electra.Message.Body.Graffiti,
+				ProposerSlashings:        b.Electra.Message.Body.ProposerSlashings,
+				AttesterSlashingsElectra: b.Electra.Message.Body.AttesterSlashings,
+				AttestationsElectra:      b.Electra.Message.Body.Attestations,
+				Deposits:                 b.Electra.Message.Body.Deposits,
+				VoluntaryExits:           b.Electra.Message.Body.VoluntaryExits,
+				SyncAggregate:            b.Electra.Message.Body.SyncAggregate,
+				ExecutionPayloadHeader: &BaseExecutionPayloadHeader{
+					ParentHash:       b.Electra.Message.Body.ExecutionPayloadHeader.ParentHash,
+					FeeRecipient:     b.Electra.Message.Body.ExecutionPayloadHeader.FeeRecipient,
+					StateRoot:        b.Electra.Message.Body.ExecutionPayloadHeader.StateRoot,
+					ReceiptsRoot:     b.Electra.Message.Body.ExecutionPayloadHeader.ReceiptsRoot,
+					LogsBloom:        b.Electra.Message.Body.ExecutionPayloadHeader.LogsBloom,
+					PrevRandao:       b.Electra.Message.Body.ExecutionPayloadHeader.PrevRandao,
+					BlockNumber:      b.Electra.Message.Body.ExecutionPayloadHeader.BlockNumber,
+					GasLimit:         b.Electra.Message.Body.ExecutionPayloadHeader.GasLimit,
+					GasUsed:          b.Electra.Message.Body.ExecutionPayloadHeader.GasUsed,
+					Timestamp:        b.Electra.Message.Body.ExecutionPayloadHeader.Timestamp,
+					ExtraData:        b.Electra.Message.Body.ExecutionPayloadHeader.ExtraData,
+					BaseFeePerGas:    b.Electra.Message.Body.ExecutionPayloadHeader.BaseFeePerGas,
+					BlockHash:        b.Electra.Message.Body.ExecutionPayloadHeader.BlockHash,
+					TransactionsRoot: b.Electra.Message.Body.ExecutionPayloadHeader.TransactionsRoot,
+					WithdrawalsRoot:  b.Electra.Message.Body.ExecutionPayloadHeader.WithdrawalsRoot,
+					BlobGasUsed:      b.Electra.Message.Body.ExecutionPayloadHeader.BlobGasUsed,
+					ExcessBlobGas:    b.Electra.Message.Body.ExecutionPayloadHeader.ExcessBlobGas,
+				},
+				BLSToExecutionChanges: b.Electra.Message.Body.BLSToExecutionChanges,
+				BlobKZGCommitments:    b.Electra.Message.Body.BlobKZGCommitments,
+				ExecutionRequests:     b.Electra.Message.Body.ExecutionRequests,
+			},
+		}
+		res.Signature = b.Electra.Signature
 	default:
 		return res, errors.New("unsupported fork version")
 	}
@@ -216,15 +267,17 @@ func (b *VersionedSignedBlindedBeaconBlock) ToVersionedSignedBeaconBlock() (Vers
 			ParentRoot:    baseSignedBlindedBeaconBlock.Message.ParentRoot,
 			StateRoot:     baseSignedBlindedBeaconBlock.Message.StateRoot,
 			Body: &BaseBeaconBlockBody{
-				RANDAOReveal:      baseSignedBlindedBeaconBlock.Message.Body.RANDAOReveal,
-				ETH1Data:          baseSignedBlindedBeaconBlock.Message.Body.ETH1Data,
-				Graffiti:          baseSignedBlindedBeaconBlock.Message.Body.Graffiti,
-				ProposerSlashings: baseSignedBlindedBeaconBlock.Message.Body.ProposerSlashings,
-				AttesterSlashings: baseSignedBlindedBeaconBlock.Message.Body.AttesterSlashings,
-				Attestations:      baseSignedBlindedBeaconBlock.Message.Body.Attestations,
-				Deposits:          baseSignedBlindedBeaconBlock.Message.Body.Deposits,
-				VoluntaryExits:    baseSignedBlindedBeaconBlock.Message.Body.VoluntaryExits,
-				SyncAggregate:     baseSignedBlindedBeaconBlock.Message.Body.SyncAggregate,
+				RANDAOReveal:             baseSignedBlindedBeaconBlock.Message.Body.RANDAOReveal,
+				ETH1Data:                 baseSignedBlindedBeaconBlock.Message.Body.ETH1Data,
+				Graffiti:                 baseSignedBlindedBeaconBlock.Message.Body.Graffiti,
+				ProposerSlashings:        baseSignedBlindedBeaconBlock.Message.Body.ProposerSlashings,
+				AttesterSlashings:        baseSignedBlindedBeaconBlock.Message.Body.AttesterSlashings,
+				Attestations:             baseSignedBlindedBeaconBlock.Message.Body.Attestations,
+				AttesterSlashingsElectra: baseSignedBlindedBeaconBlock.Message.Body.AttesterSlashingsElectra,
+				AttestationsElectra:      baseSignedBlindedBeaconBlock.Message.Body.AttestationsElectra,
+				Deposits:                 baseSignedBlindedBeaconBlock.Message.Body.Deposits,
+				VoluntaryExits:           baseSignedBlindedBeaconBlock.Message.Body.VoluntaryExits,
+				SyncAggregate:            baseSignedBlindedBeaconBlock.Message.Body.SyncAggregate,
 				ExecutionPayload: &BaseExecutionPayload{
 					ParentHash:    baseSignedBlindedBeaconBlock.Message.Body.ExecutionPayloadHeader.ParentHash,
 					FeeRecipient:  baseSignedBlindedBeaconBlock.Message.Body.ExecutionPayloadHeader.FeeRecipient,
@@ -246,6 +299,7 @@ func (b *VersionedSignedBlindedBeaconBlock) ToVersionedSignedBeaconBlock() (Vers
 				},
 				BLSToExecutionChanges: baseSignedBlindedBeaconBlock.Message.Body.BLSToExecutionChanges,
 				BlobKZGCommitments:    baseSignedBlindedBeaconBlock.Message.Body.BlobKZGCommitments,
+				ExecutionRequests:     baseSignedBlindedBeaconBlock.Message.Body.ExecutionRequests,
 			},
 		},
 		Signature: baseSignedBlindedBeaconBlock.Signature,
@@ -259,6 +313,8 @@ func (b *VersionedSignedBlindedBeaconBlock) ToVersionedSignedBeaconBlock() (Vers
 		forkVersion = spec.DataVersionCapella.String()
 	case b.Deneb != nil:
 		forkVersion = spec.DataVersionDeneb.String()
+	case b.Electra != nil:
+		forkVersion = spec.DataVersionElectra.String()
 	default:
 		return res, errors.New("unsupported fork version")
 	}
@@ -271,6 +327,211 @@ func (b *VersionedSignedBlindedBeaconBlock) ToVersionedSignedBeaconBlock() (Vers
 	return res, nil
 }
 
+// Unblind combines the VersionedSignedBlindedBeaconBlock with the full execution payload it commits to,
+// returning the VersionedSignedBeaconBlock and, from deneb onwards, the blobs bundle for its sidecars.
+// A *MismatchError is returned if the payload does not match the execution payload header of the block.
+func (b *VersionedSignedBlindedBeaconBlock) Unblind(payload *VersionedExecutionPayloadV2) (VersionedSignedBeaconBlock, *builderDenebApi.BlobsBundle, error) {
+	res := VersionedSignedBeaconBlock{}
+
+	if payload == nil {
+		return res, nil, errors.New("no ExecutionPayload set")
+	}
+
+	blockVersion, err := b.VersionNumber()
+	if err != nil {
+		return res, nil, err
+	}
+	payloadVersion, err := payload.VersionNumber()
+	if err != nil {
+		return res, nil, err
+	}
+	if blockVersion != payloadVersion {
+		return res, nil, &MismatchError{Mismatches: []FieldMismatch{{
+			Field:    "version",
+			Expected: spec.DataVersion(blockVersion).String(),
+			Actual:   spec.DataVersion(payloadVersion).String(),
+		}}}
+	}
+	version := spec.DataVersion(blockVersion)
+
+	if err := b.checkExecutionPayloadHeader(); err != nil {
+		return res, nil, err
+	}
+
+	baseSignedBlindedBeaconBlock, err := b.ToBaseSignedBlindedBeaconBlock()
+	if err != nil {
+		return res, nil, err
+	}
+
+	baseExecutionPayload, err := payload.ToBaseExecutionPayload()
+	if err != nil {
+		return res, nil, err
+	}
+
+	mismatches, err := compareExecutionPayloadHeader(version, *baseSignedBlindedBeaconBlock.Message.Body.ExecutionPayloadHeader, baseExecutionPayload)
+	if err != nil {
+		return res, nil, err
+	}
+
+	// The blobs bundle must carry exactly the commitments included in the block
+	var blobsBundle *builderDenebApi.BlobsBundle
+	if version >= spec.DataVersionDeneb {
+		blobsBundle = baseExecutionPayload.BlobsBundle
+		if blobsBundle == nil {
+			blobsBundle = &builderDenebApi.BlobsBundle{}
+		}
+		commitments := baseSignedBlindedBeaconBlock.Message.Body.BlobKZGCommitments
+		if len(commitments) != len(blobsBundle.Commitments) {
+			mismatches = append(mismatches, FieldMismatch{
+				Field:    "blob_kzg_commitments",
+				Expected: fmt.Sprintf("%d commitments", len(commitments)),
+				Actual:   fmt.Sprintf("%d commitments", len(blobsBundle.Commitments)),
+			})
+		} else {
+			for i := range commitments {
+				if commitments[i] != blobsBundle.Commitments[i] {
+					mismatches = append(mismatches, FieldMismatch{
+						Field:    fmt.Sprintf("blob_kzg_commitments[%d]", i),
+						Expected: fmt.Sprintf("%#x", commitments[i]),
+						Actual:   fmt.Sprintf("%#x", blobsBundle.Commitments[i]),
+					})
+				}
+			}
+		}
+		// Every commitment needs its proof and blob to build the sidecars
+		if len(blobsBundle.Proofs) != len(commitments) {
+			mismatches = append(mismatches, FieldMismatch{
+				Field:    "blobs_bundle.proofs",
+				Expected: fmt.Sprintf("%d proofs", len(commitments)),
+				Actual:   fmt.Sprintf("%d proofs", len(blobsBundle.Proofs)),
+			})
+		}
+		if len(blobsBundle.Blobs) != len(commitments) {
+			mismatches = append(mismatches, FieldMismatch{
+				Field:    "blobs_bundle.blobs",
+				Expected: fmt.Sprintf("%d blobs", len(commitments)),
+				Actual:   fmt.Sprintf("%d blobs", len(blobsBundle.Blobs)),
+			})
+		}
+	}
+
+	if len(mismatches) > 0 {
+		return res, nil, &MismatchError{Mismatches: mismatches}
+	}
+
+	baseSignedBeaconBlock := BaseSignedBeaconBlock{
+		Message: &BaseBeaconBlock{
+			Slot:          baseSignedBlindedBeaconBlock.Message.Slot,
+			ProposerIndex: baseSignedBlindedBeaconBlock.Message.ProposerIndex,
+			ParentRoot:    baseSignedBlindedBeaconBlock.Message.ParentRoot,
+			StateRoot:     baseSignedBlindedBeaconBlock.Message.StateRoot,
+			Body: &BaseBeaconBlockBody{
+				RANDAOReveal:             baseSignedBlindedBeaconBlock.Message.Body.RANDAOReveal,
+				ETH1Data:                 baseSignedBlindedBeaconBlock.Message.Body.ETH1Data,
+				Graffiti:                 baseSignedBlindedBeaconBlock.Message.Body.Graffiti,
+				ProposerSlashings:        baseSignedBlindedBeaconBlock.Message.Body.ProposerSlashings,
+				AttesterSlashings:        baseSignedBlindedBeaconBlock.Message.Body.AttesterSlashings,
+				Attestations:             baseSignedBlindedBeaconBlock.Message.Body.Attestations,
+				AttesterSlashingsElectra: baseSignedBlindedBeaconBlock.Message.Body.AttesterSlashingsElectra,
+				AttestationsElectra:      baseSignedBlindedBeaconBlock.Message.Body.AttestationsElectra,
+				Deposits:                 baseSignedBlindedBeaconBlock.Message.Body.Deposits,
+				VoluntaryExits:           baseSignedBlindedBeaconBlock.Message.Body.VoluntaryExits,
+				SyncAggregate:            baseSignedBlindedBeaconBlock.Message.Body.SyncAggregate,
+				ExecutionPayload:         &baseExecutionPayload,
+				BLSToExecutionChanges:    baseSignedBlindedBeaconBlock.Message.Body.BLSToExecutionChanges,
+				BlobKZGCommitments:       baseSignedBlindedBeaconBlock.Message.Body.BlobKZGCommitments,
+				ExecutionRequests:        baseSignedBlindedBeaconBlock.Message.Body.ExecutionRequests,
+			},
+		},
+		Signature: baseSignedBlindedBeaconBlock.Signature,
+	}
+
+	res, err = ConstructSignedBeaconBlock(version.String(), baseSignedBeaconBlock)
+	if err != nil {
+		return res, nil, err
+	}
+
+	return res, blobsBundle, nil
+}
+
+// checkExecutionPayloadHeader checks that the block has a message, body and execution payload header to unblind
+func (b *VersionedSignedBlindedBeaconBlock) checkExecutionPayloadHeader() error {
+	switch {
+	case b.Electra != nil:
+		if b.Electra.Message == nil {
+			return errors.New("no electra message set")
+		}
+		if b.Electra.Message.Body == nil {
+			return errors.New("no electra body set")
+		}
+		if b.Electra.Message.Body.ExecutionPayloadHeader == nil {
+			return errors.New("no electra execution payload header set")
+		}
+	case b.Deneb != nil:
+		if b.Deneb.Message == nil {
+			return errors.New("no deneb message set")
+		}
+		if b.Deneb.Message.Body == nil {
+			return errors.New("no deneb body set")
+		}
+		if b.Deneb.Message.Body.ExecutionPayloadHeader == nil {
+			return errors.New("no deneb execution payload header set")
+		}
+	case b.Capella != nil:
+		if b.Capella.Message == nil {
+			return errors.New("no capella message set")
+		}
+		if b.Capella.Message.Body == nil {
+			return errors.New("no capella body set")
+		}
+		if b.Capella.Message.Body.ExecutionPayloadHeader == nil {
+			return errors.New("no capella execution payload header set")
+		}
+	case b.Bellatrix != nil:
+		if b.Bellatrix.Message == nil {
+			return errors.New("no bellatrix message set")
+		}
+		if b.Bellatrix.Message.Body == nil {
+			return errors.New("no bellatrix body set")
+		}
+		if b.Bellatrix.Message.Body.ExecutionPayloadHeader == nil {
+			return errors.New("no bellatrix execution payload header set")
+		}
+	default:
+		return errors.New("unsupported fork version")
+	}
+	return nil
+}
+
+// Verify verifies the signature of the proposer over the block, where the domain is
+// the beacon proposer domain of the fork the block is proposed in
+func (b *VersionedSignedBlindedBeaconBlock) Verify(domain phase0.Domain, pubkey phase0.BLSPubKey) (bool, error) {
+	switch {
+	case b.Electra != nil:
+		if b.Electra.Message == nil {
+			return false, errors.New("no electra message set")
+		}
+		return VerifyObject(b.Electra.Message, domain, pubkey, b.Electra.Signature)
+	case b.Deneb != nil:
+		if b.Deneb.Message == nil {
+			return false, errors.New("no deneb message set")
+		}
+		return VerifyObject(b.Deneb.Message, domain, pubkey, b.Deneb.Signature)
+	case b.Capella != nil:
+		if b.Capella.Message == nil {
+			return false, errors.New("no capella message set")
+		}
+		return VerifyObject(b.Capella.Message, domain, pubkey, b.Capella.Signature)
+	case b.Bellatrix != nil:
+		if b.Bellatrix.Message == nil {
+			return false, errors.New("no bellatrix message set")
+		}
+		return VerifyObject(b.Bellatrix.Message, domain, pubkey, b.Bellatrix.Signature)
+	default:
+		return false, errors.New("no fork version set")
+	}
+}
+
 func (b *VersionedSignedBlindedBeaconBlock) Version() (string, error) {
 	switch {
 	case b.Bellatrix != nil:
@@ -279,6 +540,8 @@ func (b *VersionedSignedBlindedBeaconBlock) Version() (string, error) {
 		return spec.DataVersionCapella.String(), nil
 	case b.Deneb != nil:
 		return spec.DataVersionDeneb.String(), nil
+	case b.Electra != nil:
+		return spec.DataVersionElectra.String(), nil
 	default:
 		return "", errors.New("no fork version set")
 	}
@@ -292,6 +555,8 @@ func (b *VersionedSignedBlindedBeaconBlock) VersionNumber() (uint64, error) {
 		return uint64(spec.DataVersionCapella), nil
 	case b.Deneb != nil:
 		return uint64(spec.DataVersionDeneb), nil
+	case b.Electra != nil:
+		return uint64(spec.DataVersionElectra), nil
 	default:
 		return 0, errors.New("no fork version set")
 	}
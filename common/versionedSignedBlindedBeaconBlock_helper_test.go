@@ -0,0 +1,116 @@
+package common
+
+import (
+	"errors"
+	"testing"
+
+	denebApi "github.com/attestantio/go-builder-client/api/deneb"
+	apiv1electra "github.com/attestantio/go-eth2-client/api/v1/electra"
+	"github.com/attestantio/go-eth2-client/spec"
+	deneb "github.com/attestantio/go-eth2-client/spec/deneb"
+)
+
+// testUnblindElectra returns an electra blinded block and the payload it commits to, with one blob
+func testUnblindElectra(t *testing.T) (*VersionedSignedBlindedBeaconBlock, *VersionedExecutionPayloadV2) {
+	t.Helper()
+	base := testBaseExecutionPayload()
+	base.ExecutionRequests = testExecutionRequests()
+	base.BlobsBundle = &denebApi.BlobsBundle{
+		Commitments: []deneb.KZGCommitment{{0x01}},
+		Proofs:      []deneb.KZGProof{{0x02}},
+		Blobs:       []deneb.Blob{{0x03}},
+	}
+	payload, err := ConstructExecutionPayloadV2(spec.DataVersionElectra.String(), base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, err := payload.ToVersionedExecutionPayloadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block := &VersionedSignedBlindedBeaconBlock{
+		Electra: &apiv1electra.SignedBlindedBeaconBlock{
+			Message: &apiv1electra.BlindedBeaconBlock{
+				Slot: 1,
+				Body: &apiv1electra.BlindedBeaconBlockBody{
+					ExecutionPayloadHeader: header.Electra,
+					BlobKZGCommitments:     []deneb.KZGCommitment{{0x01}},
+					ExecutionRequests:      base.ExecutionRequests,
+				},
+			},
+		},
+	}
+	return block, &payload
+}
+
+func TestUnblind(t *testing.T) {
+	block, payload := testUnblindElectra(t)
+
+	res, blobsBundle, err := block.Unblind(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Electra == nil || res.Electra.Message.Body.ExecutionPayload == nil {
+		t.Fatal("block not unblinded")
+	}
+	if res.Electra.Message.Body.ExecutionRequests != block.Electra.Message.Body.ExecutionRequests {
+		t.Fatal("execution requests lost unblinding")
+	}
+	if blobsBundle == nil || len(blobsBundle.Blobs) != 1 {
+		t.Fatal("blobs bundle not returned")
+	}
+}
+
+func TestUnblindMissingFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		unset func(block *VersionedSignedBlindedBeaconBlock)
+	}{
+		{"message", func(block *VersionedSignedBlindedBeaconBlock) { block.Electra.Message = nil }},
+		{"body", func(block *VersionedSignedBlindedBeaconBlock) { block.Electra.Message.Body = nil }},
+		{"header", func(block *VersionedSignedBlindedBeaconBlock) {
+			block.Electra.Message.Body.ExecutionPayloadHeader = nil
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			block, payload := testUnblindElectra(t)
+			test.unset(block)
+			if _, _, err := block.Unblind(payload); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestUnblindBlobsBundleCounts(t *testing.T) {
+	tests := []struct {
+		name   string
+		field  string
+		modify func(bundle *denebApi.BlobsBundle)
+	}{
+		{"commitments", "blob_kzg_commitments", func(bundle *denebApi.BlobsBundle) {
+			bundle.Commitments = append(bundle.Commitments, deneb.KZGCommitment{0x04})
+		}},
+		{"proofs", "blobs_bundle.proofs", func(bundle *denebApi.BlobsBundle) { bundle.Proofs = nil }},
+		{"blobs", "blobs_bundle.blobs", func(bundle *denebApi.BlobsBundle) {
+			bundle.Blobs = append(bundle.Blobs, deneb.Blob{})
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			block, payload := testUnblindElectra(t)
+			test.modify(payload.Electra.BlobsBundle)
+
+			_, _, err := block.Unblind(payload)
+			var mismatchErr *MismatchError
+			if !errors.As(err, &mismatchErr) {
+				t.Fatalf("expected a mismatch error, got %v", err)
+			}
+			if len(mismatchErr.Mismatches) != 1 || mismatchErr.Mismatches[0].Field != test.field {
+				t.Fatalf("unexpected mismatches %v", mismatchErr.Mismatches)
+			}
+		})
+	}
+}
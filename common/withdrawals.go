@@ -0,0 +1,45 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// CapellaWithdrawalsToGeth converts consensus layer withdrawals to execution layer withdrawals, amounts stay in gwei
+func CapellaWithdrawalsToGeth(withdrawals []*capella.Withdrawal) (types.Withdrawals, error) {
+	res := make(types.Withdrawals, len(withdrawals))
+	for i, w := range withdrawals {
+		if w == nil {
+			return nil, fmt.Errorf("withdrawal %d missing", i)
+		}
+		res[i] = &types.Withdrawal{
+			Index:     uint64(w.Index),
+			Validator: uint64(w.ValidatorIndex),
+			Address:   common.Address(w.Address),
+			Amount:    uint64(w.Amount),
+		}
+	}
+	return res, nil
+}
+
+// GethWithdrawalsToCapella converts execution layer withdrawals to consensus layer withdrawals, amounts stay in gwei
+func GethWithdrawalsToCapella(withdrawals types.Withdrawals) ([]*capella.Withdrawal, error) {
+	res := make([]*capella.Withdrawal, len(withdrawals))
+	for i, w := range withdrawals {
+		if w == nil {
+			return nil, fmt.Errorf("withdrawal %d missing", i)
+		}
+		res[i] = &capella.Withdrawal{
+			Index:          capella.WithdrawalIndex(w.Index),
+			ValidatorIndex: phase0.ValidatorIndex(w.Validator),
+			Address:        bellatrix.ExecutionAddress(w.Address),
+			Amount:         phase0.Gwei(w.Amount),
+		}
+	}
+	return res, nil
+}
@@ -1,13 +1,41 @@
 package relay
 
 import (
-	"math/big"
+	"errors"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
 	ssz "github.com/ferranbt/fastssz"
 
 	commonTypes "github.com/bsn-eng/pon-golang-types/common"
-
 )
 
+// builderBlockBidFixedSize returns the size of the fixed part of the BuilderBid ssz encoding for the fork version,
+// as deneb adds the offset of the blob kzg commitments and electra the offset of the execution requests
+func builderBlockBidFixedSize(version spec.DataVersion) int {
+	switch {
+	case version >= spec.DataVersionElectra:
+		return 92
+	case version >= spec.DataVersionDeneb:
+		return 88
+	default:
+		return 84
+	}
+}
+
+// version returns the fork version of the bid, which is the version of its header
+func (b *BuilderBlockBid) version() (spec.DataVersion, error) {
+	if b.ExecutionPayloadHeader == nil {
+		return spec.DataVersionUnknown, errors.New("header missing")
+	}
+	version, err := b.ExecutionPayloadHeader.VersionNumber()
+	if err != nil {
+		return spec.DataVersionUnknown, err
+	}
+	return spec.DataVersion(version), nil
+}
+
 // MarshalSSZ ssz marshals the BuilderBid object
 func (b *BuilderBlockBid) MarshalSSZ() ([]byte, error) {
 	return ssz.MarshalSSZ(b)
@@ -15,24 +43,43 @@ func (b *BuilderBlockBid) MarshalSSZ() ([]byte, error) {
 
 // MarshalSSZTo ssz marshals the BuilderBid object to a target array
 func (b *BuilderBlockBid) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	version, err := b.version()
+	if err != nil {
+		return nil, err
+	}
+	if version >= spec.DataVersionDeneb && len(b.BlobKZGCommitments) > 4096 {
+		return nil, ssz.ErrListTooBigFn("BuilderBid.BlobKZGCommitments", len(b.BlobKZGCommitments), 4096)
+	}
+	if version >= spec.DataVersionElectra && b.ExecutionRequests == nil {
+		return nil, errors.New("execution requests missing")
+	}
+
 	dst = buf
-	offset := int(84)
+	offset := builderBlockBidFixedSize(version)
 
 	// Offset (0) 'Header'
 	dst = ssz.WriteOffset(dst, offset)
-	if b.ExecutionPayloadHeader == nil {
-		b.ExecutionPayloadHeader = new(commonTypes.VersionedExecutionPayloadHeader)
-	}
 	offset += b.ExecutionPayloadHeader.SizeSSZ()
 
-	// Field (1) 'Value'
-	value := b.Value.Bytes()
-	for i, j := 0, len(value)-1; i < j; i, j = i+1, j-1 {
-		value[i], value[j] = value[j], value[i]
+	// Offset (1) 'BlobKZGCommitments'
+	if version >= spec.DataVersionDeneb {
+		dst = ssz.WriteOffset(dst, offset)
+		offset += len(b.BlobKZGCommitments) * 48
 	}
-	dst = append(dst, value...)
 
-	// Field (2) 'Pubkey'
+	// Offset (2) 'ExecutionRequests'
+	if version >= spec.DataVersionElectra {
+		dst = ssz.WriteOffset(dst, offset)
+	}
+
+	// Field (3) 'Value'
+	value, err := commonTypes.BigIntToUint256SSZ(b.Value)
+	if err != nil {
+		return nil, err
+	}
+	dst = append(dst, value[:]...)
+
+	// Field (4) 'Pubkey'
 	dst = append(dst, b.Pubkey[:]...)
 
 	// Field (0) 'Header'
@@ -40,62 +87,156 @@ func (b *BuilderBlockBid) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 		return
 	}
 
+	// Field (1) 'BlobKZGCommitments'
+	if version >= spec.DataVersionDeneb {
+		for _, commitment := range b.BlobKZGCommitments {
+			dst = append(dst, commitment[:]...)
+		}
+	}
+
+	// Field (2) 'ExecutionRequests'
+	if version >= spec.DataVersionElectra {
+		if dst, err = b.ExecutionRequests.MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
 	return
 }
 
-// UnmarshalSSZ ssz unmarshals the BuilderBid object
+// UnmarshalSSZ ssz unmarshals the BuilderBid object. Deneb and electra bids are told apart by the size of
+// the fixed part of the encoding, while bellatrix and capella bids share a layout and their header is decoded
+// by trying each fork version in turn. Prefer UnmarshalSSZWithVersion when the fork version is known
 func (b *BuilderBlockBid) UnmarshalSSZ(buf []byte) error {
+	if len(buf) < 4 {
+		return ssz.ErrSize
+	}
+
+	switch ssz.ReadOffset(buf[0:4]) {
+	case uint64(builderBlockBidFixedSize(spec.DataVersionElectra)):
+		return b.unmarshalSSZ(spec.DataVersionElectra, buf)
+	case uint64(builderBlockBidFixedSize(spec.DataVersionDeneb)):
+		return b.unmarshalSSZ(spec.DataVersionDeneb, buf)
+	case uint64(builderBlockBidFixedSize(spec.DataVersionCapella)):
+		return b.unmarshalSSZ(spec.DataVersionUnknown, buf)
+	default:
+		return ssz.ErrInvalidVariableOffset
+	}
+}
+
+// UnmarshalSSZWithVersion ssz unmarshals the BuilderBid object with the layout and header of the given fork version
+func (b *BuilderBlockBid) UnmarshalSSZWithVersion(version spec.DataVersion, buf []byte) error {
+	if version == spec.DataVersionUnknown {
+		return errors.New("unknown bid version")
+	}
+	return b.unmarshalSSZ(version, buf)
+}
+
+func (b *BuilderBlockBid) unmarshalSSZ(version spec.DataVersion, buf []byte) error {
 	var err error
+	fixedSize := uint64(builderBlockBidFixedSize(version))
 	size := uint64(len(buf))
-	if size < 84 {
+	if size < fixedSize {
 		return ssz.ErrSize
 	}
 
 	tail := buf
-	var o0 uint64
+	var o0, o1, o2 uint64
+	headerEnd, commitmentsEnd := size, size
+	pos := uint64(4)
 
 	// Offset (0) 'Header'
 	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
 		return ssz.ErrOffset
 	}
 
-	if o0 < 84 {
+	if o0 != fixedSize {
 		return ssz.ErrInvalidVariableOffset
 	}
 
-	// Field (1) 'Value'
-	value := buf[4:36]
-	for i, j := 0, len(value)-1; i < j; i, j = i+1, j-1 {
-		value[i], value[j] = value[j], value[i]
+	// Offset (1) 'BlobKZGCommitments'
+	if version >= spec.DataVersionDeneb {
+		if o1 = ssz.ReadOffset(buf[4:8]); o1 > size || o0 > o1 {
+			return ssz.ErrOffset
+		}
+		headerEnd = o1
+		pos = 8
 	}
-	b.Value = new(big.Int)
-	b.Value.SetBytes(value)
 
-	// Field (2) 'Pubkey'
-	copy(b.Pubkey[:], buf[36:84])
+	// Offset (2) 'ExecutionRequests'
+	if version >= spec.DataVersionElectra {
+		if o2 = ssz.ReadOffset(buf[8:12]); o2 > size || o1 > o2 {
+			return ssz.ErrOffset
+		}
+		commitmentsEnd = o2
+		pos = 12
+	}
+
+	// Field (3) 'Value'
+	b.Value = commonTypes.Uint256SSZToBigInt(buf[pos : pos+32])
+
+	// Field (4) 'Pubkey'
+	copy(b.Pubkey[:], buf[pos+32:pos+80])
 
 	// Field (0) 'Header'
 	{
-		buf = tail[o0:]
-		if b.ExecutionPayloadHeader == nil {
-			b.ExecutionPayloadHeader = new(commonTypes.VersionedExecutionPayloadHeader)
+		buf = tail[o0:headerEnd]
+		b.ExecutionPayloadHeader = new(commonTypes.VersionedExecutionPayloadHeader)
+		if version == spec.DataVersionUnknown {
+			err = b.ExecutionPayloadHeader.UnmarshalSSZ(buf)
+		} else {
+			err = b.ExecutionPayloadHeader.UnmarshalSSZWithVersion(version, buf)
+		}
+		if err != nil {
+			return err
 		}
-		if err = b.ExecutionPayloadHeader.UnmarshalSSZ(buf); err != nil {
+	}
+
+	// Field (1) 'BlobKZGCommitments'
+	b.BlobKZGCommitments = nil
+	if version >= spec.DataVersionDeneb {
+		buf = tail[o1:commitmentsEnd]
+		num, err := ssz.DivideInt2(len(buf), 48, 4096)
+		if err != nil {
 			return err
 		}
+		b.BlobKZGCommitments = make([]deneb.KZGCommitment, num)
+		for i := 0; i < num; i++ {
+			copy(b.BlobKZGCommitments[i][:], buf[i*48:(i+1)*48])
+		}
 	}
+
+	// Field (2) 'ExecutionRequests'
+	b.ExecutionRequests = nil
+	if version >= spec.DataVersionElectra {
+		b.ExecutionRequests = new(electra.ExecutionRequests)
+		if err = b.ExecutionRequests.UnmarshalSSZ(tail[o2:]); err != nil {
+			return err
+		}
+	}
+
 	return err
 }
 
 // SizeSSZ returns the ssz encoded size in bytes for the BuilderBid object
 func (b *BuilderBlockBid) SizeSSZ() (size int) {
-	size = 84
+	version, _ := b.version()
+	size = builderBlockBidFixedSize(version)
 
 	// Field (0) 'Header'
-	if b.ExecutionPayloadHeader == nil {
-		b.ExecutionPayloadHeader = new(commonTypes.VersionedExecutionPayloadHeader)
+	if b.ExecutionPayloadHeader != nil {
+		size += b.ExecutionPayloadHeader.SizeSSZ()
+	}
+
+	// Field (1) 'BlobKZGCommitments'
+	if version >= spec.DataVersionDeneb {
+		size += len(b.BlobKZGCommitments) * 48
+	}
+
+	// Field (2) 'ExecutionRequests'
+	if version >= spec.DataVersionElectra && b.ExecutionRequests != nil {
+		size += b.ExecutionRequests.SizeSSZ()
 	}
-	size += b.ExecutionPayloadHeader.SizeSSZ()
 
 	return
 }
@@ -105,8 +246,28 @@ func (b *BuilderBlockBid) HashTreeRoot() ([32]byte, error) {
 	return ssz.HashWithDefaultHasher(b)
 }
 
+// LegacyHashTreeRoot ssz hashes the BuilderBid object with the value encoded as its minimal little endian
+// bytes, which differs from HashTreeRoot for a zero value. Only use it to verify signatures made over the legacy root
+func (b *BuilderBlockBid) LegacyHashTreeRoot() ([32]byte, error) {
+	hh := ssz.DefaultHasherPool.Get()
+	defer ssz.DefaultHasherPool.Put(hh)
+	if err := b.hashTreeRootWith(hh, true); err != nil {
+		return [32]byte{}, err
+	}
+	return hh.HashRoot()
+}
+
 // HashTreeRootWith ssz hashes the BuilderBid object with a hasher
 func (b *BuilderBlockBid) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	return b.hashTreeRootWith(hh, false)
+}
+
+func (b *BuilderBlockBid) hashTreeRootWith(hh ssz.HashWalker, legacyValue bool) (err error) {
+	version, err := b.version()
+	if err != nil {
+		return err
+	}
+
 	indx := hh.Index()
 
 	// Field (0) 'Header'
@@ -114,14 +275,40 @@ func (b *BuilderBlockBid) HashTreeRootWith(hh ssz.HashWalker) (err error) {
 		return
 	}
 
-	// Field (1) 'Value'
-	value := b.Value.Bytes()
-	for i, j := 0, len(value)-1; i < j; i, j = i+1, j-1 {
-		value[i], value[j] = value[j], value[i]
+	// Field (1) 'BlobKZGCommitments'
+	if version >= spec.DataVersionDeneb {
+		if size := len(b.BlobKZGCommitments); size > 4096 {
+			return ssz.ErrListTooBigFn("BuilderBid.BlobKZGCommitments", size, 4096)
+		}
+		subIndx := hh.Index()
+		for _, commitment := range b.BlobKZGCommitments {
+			hh.PutBytes(commitment[:])
+		}
+		hh.MerkleizeWithMixin(subIndx, uint64(len(b.BlobKZGCommitments)), 4096)
 	}
-	hh.PutBytes(value)
 
-	// Field (2) 'Pubkey'
+	// Field (2) 'ExecutionRequests'
+	if version >= spec.DataVersionElectra {
+		if b.ExecutionRequests == nil {
+			return errors.New("execution requests missing")
+		}
+		if err = b.ExecutionRequests.HashTreeRootWith(hh); err != nil {
+			return
+		}
+	}
+
+	// Field (3) 'Value'
+	if legacyValue {
+		hh.PutBytes(commonTypes.BigIntToLegacySSZBytes(b.Value))
+	} else {
+		value, err := commonTypes.BigIntToUint256SSZ(b.Value)
+		if err != nil {
+			return err
+		}
+		hh.PutBytes(value[:])
+	}
+
+	// Field (4) 'Pubkey'
 	hh.PutBytes(b.Pubkey[:])
 
 	hh.Merkleize(indx)
@@ -131,4 +318,4 @@ func (b *BuilderBlockBid) HashTreeRootWith(hh ssz.HashWalker) (err error) {
 // GetTree ssz hashes the BuilderBid object
 func (b *BuilderBlockBid) GetTree() (*ssz.Node, error) {
 	return ssz.ProofTree(b)
-}
\ No newline at end of file
+}
@@ -0,0 +1,186 @@
+package relay
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+
+	commonTypes "github.com/bsn-eng/pon-golang-types/common"
+)
+
+var testVersions = []spec.DataVersion{
+	spec.DataVersionBellatrix,
+	spec.DataVersionCapella,
+	spec.DataVersionDeneb,
+	spec.DataVersionElectra,
+}
+
+func testBuilderBlockBid(t *testing.T, version spec.DataVersion) *BuilderBlockBid {
+	t.Helper()
+	payload, err := commonTypes.ConstructExecutionPayload(version.String(), commonTypes.BaseExecutionPayload{
+		BlockNumber:   100,
+		GasLimit:      30000000,
+		Timestamp:     1700000000,
+		ExtraData:     []byte{0x01},
+		BaseFeePerGas: uint256.NewInt(7),
+		Transactions:  []bellatrix.Transaction{{0x02}},
+		Withdrawals:   []*capella.Withdrawal{{Index: 1, Amount: 2}},
+		BlobGasUsed:   131072,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, err := payload.ToVersionedExecutionPayloadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bid := &BuilderBlockBid{
+		Pubkey:                 phase0.BLSPubKey{0x03},
+		Value:                  big.NewInt(1000000000),
+		ExecutionPayloadHeader: &header,
+	}
+	if version >= spec.DataVersionDeneb {
+		bid.BlobKZGCommitments = []deneb.KZGCommitment{{0x04}, {0x05}}
+	}
+	if version >= spec.DataVersionElectra {
+		bid.ExecutionRequests = &electra.ExecutionRequests{
+			Deposits: []*electra.DepositRequest{{
+				Pubkey:                phase0.BLSPubKey{0x06},
+				WithdrawalCredentials: make([]byte, 32),
+				Amount:                32000000000,
+				Index:                 1,
+			}},
+			Withdrawals:    []*electra.WithdrawalRequest{},
+			Consolidations: []*electra.ConsolidationRequest{},
+		}
+	}
+	return bid
+}
+
+func TestBuilderBlockBidCheckHashTreeRoot(t *testing.T) {
+	for _, version := range testVersions {
+		bid := testBuilderBlockBid(t, version)
+		if err := bid.CheckHashTreeRoot(); err != nil {
+			t.Fatalf("%s: %v", version, err)
+		}
+	}
+}
+
+func TestBuilderBlockBidSSZ(t *testing.T) {
+	for _, version := range testVersions {
+		bid := testBuilderBlockBid(t, version)
+		data, err := bid.MarshalSSZ()
+		if err != nil {
+			t.Fatalf("%s: %v", version, err)
+		}
+		if len(data) != bid.SizeSSZ() {
+			t.Fatalf("%s: expected %d bytes, got %d", version, bid.SizeSSZ(), len(data))
+		}
+
+		// The encoding must match the go-builder-client bid of the same fork
+		versionedBid, err := (&SignedBuilderBlockBid{Message: bid}).ToVersionedSignedBuilderBid()
+		if err != nil {
+			t.Fatalf("%s: %v", version, err)
+		}
+		var expected []byte
+		switch version {
+		case spec.DataVersionBellatrix:
+			expected, err = versionedBid.Bellatrix.Message.MarshalSSZ()
+		case spec.DataVersionCapella:
+			expected, err = versionedBid.Capella.Message.MarshalSSZ()
+		case spec.DataVersionDeneb:
+			expected, err = versionedBid.Deneb.Message.MarshalSSZ()
+		case spec.DataVersionElectra:
+			expected, err = versionedBid.Electra.Message.MarshalSSZ()
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", version, err)
+		}
+		if !bytes.Equal(expected, data) {
+			t.Fatalf("%s: encoding differs from go-builder-client", version)
+		}
+
+		for _, withVersion := range []bool{true, false} {
+			res := &BuilderBlockBid{}
+			if withVersion {
+				err = res.UnmarshalSSZWithVersion(version, data)
+			} else {
+				err = res.UnmarshalSSZ(data)
+			}
+			if err != nil {
+				t.Fatalf("%s: %v", version, err)
+			}
+			resVersion, err := res.version()
+			if err != nil {
+				t.Fatalf("%s: %v", version, err)
+			}
+			if resVersion != version {
+				t.Fatalf("%s: decoded as %s", version, resVersion)
+			}
+			if err := res.CheckHashTreeRoot(); err != nil {
+				t.Fatalf("%s: %v", version, err)
+			}
+			if res.Value.Cmp(bid.Value) != 0 || len(res.BlobKZGCommitments) != len(bid.BlobKZGCommitments) {
+				t.Fatalf("%s: bid changed through ssz", version)
+			}
+		}
+	}
+}
+
+func TestBuilderBlockBidSSZMissingFields(t *testing.T) {
+	bid := testBuilderBlockBid(t, spec.DataVersionElectra)
+	bid.ExecutionRequests = nil
+	if _, err := bid.MarshalSSZ(); err == nil {
+		t.Fatal("expected an error for missing execution requests")
+	}
+	if _, err := bid.HashTreeRoot(); err == nil {
+		t.Fatal("expected an error for missing execution requests")
+	}
+
+	bid.ExecutionPayloadHeader = nil
+	if _, err := bid.MarshalSSZ(); err == nil {
+		t.Fatal("expected an error for a missing header")
+	}
+	if bid.ExecutionPayloadHeader != nil {
+		t.Fatal("encoding set the header of the bid")
+	}
+}
+
+func TestBuilderBlockBidJSON(t *testing.T) {
+	for _, version := range testVersions {
+		bid := testBuilderBlockBid(t, version)
+		data, err := bid.MarshalJSON()
+		if err != nil {
+			t.Fatalf("%s: %v", version, err)
+		}
+
+		res := &BuilderBlockBid{}
+		if err := res.UnmarshalJSON(data); err != nil {
+			t.Fatalf("%s: %v", version, err)
+		}
+		resVersion, err := res.version()
+		if err != nil {
+			t.Fatalf("%s: %v", version, err)
+		}
+		if resVersion != version {
+			t.Fatalf("%s: decoded as %s", version, resVersion)
+		}
+		expected, _ := bid.HashTreeRoot()
+		actual, err := res.HashTreeRoot()
+		if err != nil {
+			t.Fatalf("%s: %v", version, err)
+		}
+		if expected != actual {
+			t.Fatalf("%s: bid changed through json", version)
+		}
+	}
+}
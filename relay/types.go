@@ -8,8 +8,17 @@ import (
 
 	commonTypes "github.com/bsn-eng/pon-golang-types/common"
 
+	builderBellatrixApi "github.com/attestantio/go-builder-client/api/bellatrix"
+	builderCapellaApi "github.com/attestantio/go-builder-client/api/capella"
+	builderDenebApi "github.com/attestantio/go-builder-client/api/deneb"
+	builderElectraApi "github.com/attestantio/go-builder-client/api/electra"
+	builderSpec "github.com/attestantio/go-builder-client/spec"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/holiman/uint256"
 )
 
 type Address [20]byte
@@ -45,6 +54,7 @@ func (t Transaction) String() string {
 	return string(transaction)
 }
 
+// Deprecated: use ValidatorRegistry, which does not need to be locked by callers
 type ValidatorIndexes struct {
 	Mu                   sync.Mutex
 	ValidatorPubkeyIndex map[string]uint64
@@ -57,6 +67,164 @@ type SignedBuilderBlockBid struct {
 	Signature phase0.BLSSignature `ssz-size:"96"`
 }
 
+// SignBuilderBlockBid signs the bid in the builder domain with the secret key of the relay
+func SignBuilderBlockBid(bid *BuilderBlockBid, domain phase0.Domain, secretKey *commonTypes.BLSSecretKey) (*SignedBuilderBlockBid, error) {
+	if bid == nil {
+		return nil, errors.New("no bid set")
+	}
+	signature, err := commonTypes.SignObject(bid, domain, secretKey)
+	if err != nil {
+		return nil, err
+	}
+	return &SignedBuilderBlockBid{
+		Message:   bid,
+		Signature: signature,
+	}, nil
+}
+
+// Verify verifies the signature over the bid by the bid pubkey in the builder domain
+func (s *SignedBuilderBlockBid) Verify(domain phase0.Domain) (bool, error) {
+	if s.Message == nil {
+		return false, errors.New("no bid set")
+	}
+	return commonTypes.VerifyObject(s.Message, domain, s.Message.Pubkey, s.Signature)
+}
+
+// VerifyLegacy verifies a signature made over the legacy root of the bid, see BuilderBlockBid.LegacyHashTreeRoot
+func (s *SignedBuilderBlockBid) VerifyLegacy(domain phase0.Domain) (bool, error) {
+	if s.Message == nil {
+		return false, errors.New("no bid set")
+	}
+	root, err := s.Message.LegacyHashTreeRoot()
+	if err != nil {
+		return false, err
+	}
+	signingData := phase0.SigningData{
+		ObjectRoot: root,
+		Domain:     domain,
+	}
+	signingRoot, err := signingData.HashTreeRoot()
+	if err != nil {
+		return false, err
+	}
+	return commonTypes.VerifyRoot(signingRoot, s.Message.Pubkey, s.Signature)
+}
+
+// ToVersionedSignedBuilderBid converts the signed bid to the go-builder-client signed bid of the same fork
+func (s *SignedBuilderBlockBid) ToVersionedSignedBuilderBid() (*builderSpec.VersionedSignedBuilderBid, error) {
+	if s.Message == nil {
+		return nil, errors.New("no bid set")
+	}
+	if s.Message.ExecutionPayloadHeader == nil {
+		return nil, errors.New("header missing")
+	}
+	if s.Message.Value == nil {
+		return nil, errors.New("value missing")
+	}
+	value, overflow := uint256.FromBig(s.Message.Value)
+	if overflow || s.Message.Value.Sign() < 0 {
+		return nil, errors.New("value does not fit in a uint256")
+	}
+
+	header := s.Message.ExecutionPayloadHeader
+	switch {
+	case header.Electra != nil:
+		if s.Message.ExecutionRequests == nil {
+			return nil, errors.New("execution requests missing")
+		}
+		return &builderSpec.VersionedSignedBuilderBid{
+			Version: spec.DataVersionElectra,
+			Electra: &builderElectraApi.SignedBuilderBid{
+				Message: &builderElectraApi.BuilderBid{
+					Header:             header.Electra,
+					BlobKZGCommitments: s.Message.BlobKZGCommitments,
+					ExecutionRequests:  s.Message.ExecutionRequests,
+					Value:              value,
+					Pubkey:             s.Message.Pubkey,
+				},
+				Signature: s.Signature,
+			},
+		}, nil
+	case header.Deneb != nil:
+		return &builderSpec.VersionedSignedBuilderBid{
+			Version: spec.DataVersionDeneb,
+			Deneb: &builderDenebApi.SignedBuilderBid{
+				Message: &builderDenebApi.BuilderBid{
+					Header:             header.Deneb,
+					BlobKZGCommitments: s.Message.BlobKZGCommitments,
+					Value:              value,
+					Pubkey:             s.Message.Pubkey,
+				},
+				Signature: s.Signature,
+			},
+		}, nil
+	case header.Capella != nil:
+		return &builderSpec.VersionedSignedBuilderBid{
+			Version: spec.DataVersionCapella,
+			Capella: &builderCapellaApi.SignedBuilderBid{
+				Message: &builderCapellaApi.BuilderBid{
+					Header: header.Capella,
+					Value:  value,
+					Pubkey: s.Message.Pubkey,
+				},
+				Signature: s.Signature,
+			},
+		}, nil
+	case header.Bellatrix != nil:
+		return &builderSpec.VersionedSignedBuilderBid{
+			Version: spec.DataVersionBellatrix,
+			Bellatrix: &builderBellatrixApi.SignedBuilderBid{
+				Message: &builderBellatrixApi.BuilderBid{
+					Header: header.Bellatrix,
+					Value:  value,
+					Pubkey: s.Message.Pubkey,
+				},
+				Signature: s.Signature,
+			},
+		}, nil
+	default:
+		return nil, errors.New("no fork version set")
+	}
+}
+
+// CheckHashTreeRoot checks that the root of the bid matches the root of the equivalent go-builder-client bid,
+// so that signatures over the bid verify in third party software
+func (b *BuilderBlockBid) CheckHashTreeRoot() error {
+	signedBid := &SignedBuilderBlockBid{Message: b}
+	versionedBid, err := signedBid.ToVersionedSignedBuilderBid()
+	if err != nil {
+		return err
+	}
+
+	var expected [32]byte
+	switch versionedBid.Version {
+	case spec.DataVersionElectra:
+		expected, err = versionedBid.Electra.Message.HashTreeRoot()
+	case spec.DataVersionDeneb:
+		expected, err = versionedBid.Deneb.Message.HashTreeRoot()
+	case spec.DataVersionCapella:
+		expected, err = versionedBid.Capella.Message.HashTreeRoot()
+	case spec.DataVersionBellatrix:
+		expected, err = versionedBid.Bellatrix.Message.HashTreeRoot()
+	}
+	if err != nil {
+		return err
+	}
+
+	actual, err := b.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	if expected != actual {
+		return &commonTypes.MismatchError{Mismatches: []commonTypes.FieldMismatch{{
+			Field:    "hash_tree_root",
+			Expected: hexutil.Encode(expected[:]),
+			Actual:   hexutil.Encode(actual[:]),
+		}}}
+	}
+	return nil
+}
+
 // BuilderBlockBid is a BuilderBlockBid similar to builder.BuilderBlockBid
 // This is just leaner with only necessary fields passed to valiator proxy software
 type BuilderBlockBid struct {
@@ -67,12 +235,19 @@ type BuilderBlockBid struct {
 
 	ExecutionPayloadHeader *commonTypes.VersionedExecutionPayloadHeader `json:"header"`
 	// json feild name has been changed from execution_payload_header to header for mevBoost
+
+	// BlobKZGCommitments are committed to by deneb and later bids
+	BlobKZGCommitments []deneb.KZGCommitment `json:"blob_kzg_commitments" ssz-max:"4096" ssz-size:"?,48"`
+	// ExecutionRequests are committed to by electra and later bids
+	ExecutionRequests *electra.ExecutionRequests `json:"execution_requests"`
 }
 
 type builderBlockBidJSON struct {
 	Pubkey string `json:"pubkey" ssz-size:"48"`
 	Value          string `json:"value"`
 	ExecutionPayloadHeader *commonTypes.VersionedExecutionPayloadHeader `json:"header"`
+	BlobKZGCommitments     []deneb.KZGCommitment                        `json:"blob_kzg_commitments,omitempty"`
+	ExecutionRequests      *electra.ExecutionRequests                   `json:"execution_requests,omitempty"`
 }
 
 func (b *BuilderBlockBid) MarshalJSON() ([]byte, error) {
@@ -80,6 +255,8 @@ func (b *BuilderBlockBid) MarshalJSON() ([]byte, error) {
 		Pubkey: b.Pubkey.String(),
 		Value:          b.Value.String(),
 		ExecutionPayloadHeader: b.ExecutionPayloadHeader,
+		BlobKZGCommitments:     b.BlobKZGCommitments,
+		ExecutionRequests:      b.ExecutionRequests,
 	})
 }
 
@@ -113,7 +290,27 @@ func (b *BuilderBlockBid) unpack(data *builderBlockBidJSON) error {
 	if data.ExecutionPayloadHeader == nil {
 		return errors.New("header missing")
 	}
-	b.ExecutionPayloadHeader = data.ExecutionPayloadHeader
+	// An electra header decodes as deneb in JSON, so a bid with execution requests is an electra bid
+	header := data.ExecutionPayloadHeader
+	if header.Deneb != nil && data.ExecutionRequests != nil {
+		header.Electra, header.Deneb = header.Deneb, nil
+	}
+	b.ExecutionPayloadHeader = header
+
+	b.BlobKZGCommitments = nil
+	b.ExecutionRequests = nil
+	if header.Deneb != nil || header.Electra != nil {
+		b.BlobKZGCommitments = data.BlobKZGCommitments
+		if b.BlobKZGCommitments == nil {
+			b.BlobKZGCommitments = []deneb.KZGCommitment{}
+		}
+	}
+	if header.Electra != nil {
+		if data.ExecutionRequests == nil {
+			return errors.New("execution requests missing")
+		}
+		b.ExecutionRequests = data.ExecutionRequests
+	}
 
 	return nil
 
@@ -0,0 +1,57 @@
+package relay
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	commonTypes "github.com/bsn-eng/pon-golang-types/common"
+)
+
+func TestSignBuilderBlockBid(t *testing.T) {
+	secretKey, err := commonTypes.BLSSecretKeyFromBytes(append(make([]byte, 31), 0x01))
+	if err != nil {
+		t.Fatal(err)
+	}
+	domain, err := commonTypes.ComputeBuilderDomain(phase0.Version{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bid := &BuilderBlockBid{
+		Pubkey: commonTypes.BLSPublicKeyFromSecretKey(secretKey),
+		Value:  big.NewInt(1000000000),
+		ExecutionPayloadHeader: &commonTypes.VersionedExecutionPayloadHeader{
+			Capella: &capella.ExecutionPayloadHeader{
+				BlockNumber: 100,
+				GasLimit:    30000000,
+				ExtraData:   []byte{0x01},
+			},
+		},
+	}
+	signedBid, err := SignBuilderBlockBid(bid, domain, secretKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := signedBid.Verify(domain); err != nil || !ok {
+		t.Fatalf("signature not verified: %v", err)
+	}
+	// The roots only differ for a zero value, so a signature over a non zero value verifies both ways
+	if ok, err := signedBid.VerifyLegacy(domain); err != nil || !ok {
+		t.Fatalf("legacy signature not verified: %v", err)
+	}
+
+	signedBid.Message.Value = big.NewInt(1)
+	if ok, _ := signedBid.Verify(domain); ok {
+		t.Fatal("signature verified for a changed bid")
+	}
+
+	if _, err := SignBuilderBlockBid(nil, domain, secretKey); err == nil {
+		t.Fatal("expected an error for a missing bid")
+	}
+	if _, err := (&SignedBuilderBlockBid{}).Verify(domain); err == nil {
+		t.Fatal("expected an error for a missing bid")
+	}
+}
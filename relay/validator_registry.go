@@ -0,0 +1,232 @@
+package relay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/bsn-eng/pon-golang-types/beaconclient"
+)
+
+// ValidatorsProvider fetches validators from the beacon node, implemented by beaconclient.Client
+type ValidatorsProvider interface {
+	Validators(ctx context.Context, stateID string, ids []string) ([]*beaconclient.ValidatorData, error)
+}
+
+// ValidatorRegistry maps validator public keys to indexes and holds their beacon chain status.
+// Updates copy the registry and swap it in, so lookups never wait on a refresh
+type ValidatorRegistry struct {
+	snapshot atomic.Pointer[ValidatorSnapshot]
+	// Serializes updates, lookups do not take it
+	mu sync.Mutex
+}
+
+// ValidatorSnapshot is an immutable view of the registry at one point in time
+type ValidatorSnapshot struct {
+	byIndex  map[uint64]*beaconclient.ValidatorData
+	byPubkey map[string]uint64
+	indexes  []uint64
+}
+
+func NewValidatorRegistry() *ValidatorRegistry {
+	registry := &ValidatorRegistry{}
+	registry.snapshot.Store(newValidatorSnapshot(nil))
+	return registry
+}
+
+// Load replaces the validators in the registry with those in the response
+func (r *ValidatorRegistry) Load(res *beaconclient.GetValidatorsResponse) error {
+	if res == nil {
+		return errors.New("no validators response set")
+	}
+
+	snapshot := newValidatorSnapshot(nil)
+	if err := snapshot.upsert(res.Data); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.snapshot.Store(snapshot)
+	return nil
+}
+
+// Update adds new validators to the registry and replaces the status and balance of known ones,
+// keeping every other validator as it is
+func (r *ValidatorRegistry) Update(validators []*beaconclient.ValidatorData) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	snapshot := newValidatorSnapshot(r.snapshot.Load())
+	if err := snapshot.upsert(validators); err != nil {
+		return err
+	}
+	r.snapshot.Store(snapshot)
+	return nil
+}
+
+// Refresh fetches the validators in the state and updates the registry with them. Only the validators
+// with the given indexes or public keys are fetched if ids are given, e.g. those still pending activation
+func (r *ValidatorRegistry) Refresh(ctx context.Context, provider ValidatorsProvider, stateID string, ids []string) error {
+	validators, err := provider.Validators(ctx, stateID, ids)
+	if err != nil {
+		return err
+	}
+	return r.Update(validators)
+}
+
+// Snapshot returns the current view of the registry, which later updates do not change
+func (r *ValidatorRegistry) Snapshot() *ValidatorSnapshot {
+	return r.snapshot.Load()
+}
+
+func (r *ValidatorRegistry) Len() int {
+	return r.Snapshot().Len()
+}
+
+func (r *ValidatorRegistry) ByIndex(index uint64) (*beaconclient.ValidatorData, bool) {
+	return r.Snapshot().ByIndex(index)
+}
+
+func (r *ValidatorRegistry) ByPubkey(pubkey string) (*beaconclient.ValidatorData, bool) {
+	return r.Snapshot().ByPubkey(pubkey)
+}
+
+func (r *ValidatorRegistry) IndexOf(pubkey string) (uint64, bool) {
+	return r.Snapshot().IndexOf(pubkey)
+}
+
+func (r *ValidatorRegistry) PubkeyOf(index uint64) (string, bool) {
+	return r.Snapshot().PubkeyOf(index)
+}
+
+// Filter returns the validators with any of the statuses, ordered by index
+func (r *ValidatorRegistry) Filter(statuses ...beaconclient.ValidatorStatus) []*beaconclient.ValidatorData {
+	return r.Snapshot().Filter(statuses...)
+}
+
+// newValidatorSnapshot returns a copy of the snapshot, or an empty snapshot if none is given
+func newValidatorSnapshot(from *ValidatorSnapshot) *ValidatorSnapshot {
+	if from == nil {
+		return &ValidatorSnapshot{
+			byIndex:  make(map[uint64]*beaconclient.ValidatorData),
+			byPubkey: make(map[string]uint64),
+		}
+	}
+
+	snapshot := &ValidatorSnapshot{
+		byIndex:  make(map[uint64]*beaconclient.ValidatorData, len(from.byIndex)),
+		byPubkey: make(map[string]uint64, len(from.byPubkey)),
+		indexes:  make([]uint64, len(from.indexes)),
+	}
+	for index, validator := range from.byIndex {
+		snapshot.byIndex[index] = validator
+	}
+	for pubkey, index := range from.byPubkey {
+		snapshot.byPubkey[pubkey] = index
+	}
+	copy(snapshot.indexes, from.indexes)
+	return snapshot
+}
+
+// upsert must only be called on a snapshot that has not been published yet
+func (s *ValidatorSnapshot) upsert(validators []*beaconclient.ValidatorData) error {
+	added := false
+	for _, validator := range validators {
+		if validator == nil {
+			return errors.New("validator missing")
+		}
+		pubkey := normalizePubkey(validator.Validator.Pubkey)
+		if pubkey == "" {
+			return errors.New("validator pubkey missing")
+		}
+
+		if known, ok := s.byPubkey[pubkey]; ok && known != validator.Index {
+			return fmt.Errorf("validator pubkey %s already registered with index %d", pubkey, known)
+		}
+		if existing, ok := s.byIndex[validator.Index]; ok {
+			delete(s.byPubkey, normalizePubkey(existing.Validator.Pubkey))
+		} else {
+			s.indexes = append(s.indexes, validator.Index)
+			added = true
+		}
+
+		entry := *validator
+		s.byIndex[validator.Index] = &entry
+		s.byPubkey[pubkey] = validator.Index
+	}
+
+	if added {
+		sort.Slice(s.indexes, func(i, j int) bool { return s.indexes[i] < s.indexes[j] })
+	}
+	return nil
+}
+
+func (s *ValidatorSnapshot) Len() int {
+	return len(s.indexes)
+}
+
+// ByIndex returns the validator with the index. The returned validator is shared and must not be modified
+func (s *ValidatorSnapshot) ByIndex(index uint64) (*beaconclient.ValidatorData, bool) {
+	validator, ok := s.byIndex[index]
+	return validator, ok
+}
+
+// ByPubkey returns the validator with the hex public key. The returned validator is shared and must not be modified
+func (s *ValidatorSnapshot) ByPubkey(pubkey string) (*beaconclient.ValidatorData, bool) {
+	index, ok := s.byPubkey[normalizePubkey(pubkey)]
+	if !ok {
+		return nil, false
+	}
+	return s.ByIndex(index)
+}
+
+func (s *ValidatorSnapshot) IndexOf(pubkey string) (uint64, bool) {
+	index, ok := s.byPubkey[normalizePubkey(pubkey)]
+	return index, ok
+}
+
+func (s *ValidatorSnapshot) PubkeyOf(index uint64) (string, bool) {
+	validator, ok := s.byIndex[index]
+	if !ok {
+		return "", false
+	}
+	return validator.Validator.Pubkey, true
+}
+
+// Filter returns the validators with any of the statuses, ordered by index. Statuses are either
+// beacon API statuses such as active_ongoing, or their groups pending, active, exited and withdrawal
+func (s *ValidatorSnapshot) Filter(statuses ...beaconclient.ValidatorStatus) []*beaconclient.ValidatorData {
+	validators := []*beaconclient.ValidatorData{}
+	s.Range(func(validator *beaconclient.ValidatorData) bool {
+		for _, status := range statuses {
+			if validator.Status.Matches(status) {
+				validators = append(validators, validator)
+				break
+			}
+		}
+		return true
+	})
+	return validators
+}
+
+// Range calls fn for each validator in index order until fn returns false
+func (s *ValidatorSnapshot) Range(fn func(validator *beaconclient.ValidatorData) bool) {
+	for _, index := range s.indexes {
+		if !fn(s.byIndex[index]) {
+			return
+		}
+	}
+}
+
+func normalizePubkey(pubkey string) string {
+	pubkey = strings.ToLower(pubkey)
+	if pubkey != "" && !strings.HasPrefix(pubkey, "0x") {
+		pubkey = "0x" + pubkey
+	}
+	return pubkey
+}
@@ -0,0 +1,141 @@
+package relay
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsn-eng/pon-golang-types/beaconclient"
+)
+
+func testValidator(index uint64, pubkey string, status beaconclient.ValidatorStatus) *beaconclient.ValidatorData {
+	return &beaconclient.ValidatorData{
+		Index:     index,
+		Status:    status,
+		Validator: beaconclient.ValidatorDetails{Pubkey: pubkey},
+	}
+}
+
+func TestValidatorRegistryLookups(t *testing.T) {
+	registry := NewValidatorRegistry()
+	err := registry.Load(&beaconclient.GetValidatorsResponse{Data: []*beaconclient.ValidatorData{
+		testValidator(3, "0xAA", beaconclient.ValidatorStatusActiveOngoing),
+		testValidator(1, "bb", beaconclient.ValidatorStatusPendingQueued),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if registry.Len() != 2 {
+		t.Fatalf("expected 2 validators, got %d", registry.Len())
+	}
+	if index, ok := registry.IndexOf("0xaa"); !ok || index != 3 {
+		t.Fatal("validator not found by lower case pubkey")
+	}
+	if validator, ok := registry.ByPubkey("0xBB"); !ok || validator.Index != 1 {
+		t.Fatal("validator not found by pubkey without prefix")
+	}
+	if pubkey, ok := registry.PubkeyOf(3); !ok || pubkey != "0xAA" {
+		t.Fatalf("unexpected pubkey %s", pubkey)
+	}
+	if _, ok := registry.ByIndex(2); ok {
+		t.Fatal("unknown validator found")
+	}
+
+	var indexes []uint64
+	registry.Snapshot().Range(func(validator *beaconclient.ValidatorData) bool {
+		indexes = append(indexes, validator.Index)
+		return true
+	})
+	if len(indexes) != 2 || indexes[0] != 1 || indexes[1] != 3 {
+		t.Fatalf("validators not in index order: %v", indexes)
+	}
+}
+
+func TestValidatorRegistrySnapshotIsolation(t *testing.T) {
+	registry := NewValidatorRegistry()
+	if err := registry.Update([]*beaconclient.ValidatorData{testValidator(1, "0x01", beaconclient.ValidatorStatusPendingQueued)}); err != nil {
+		t.Fatal(err)
+	}
+	snapshot := registry.Snapshot()
+
+	validator := testValidator(1, "0x01", beaconclient.ValidatorStatusActiveOngoing)
+	if err := registry.Update([]*beaconclient.ValidatorData{validator, testValidator(2, "0x02", beaconclient.ValidatorStatusActiveOngoing)}); err != nil {
+		t.Fatal(err)
+	}
+	// The registry keeps its own copy of updated validators
+	validator.Status = beaconclient.ValidatorStatusExitedSlashed
+
+	if snapshot.Len() != 1 {
+		t.Fatalf("earlier snapshot changed to %d validators", snapshot.Len())
+	}
+	if old, _ := snapshot.ByIndex(1); old.Status != beaconclient.ValidatorStatusPendingQueued {
+		t.Fatalf("earlier snapshot status changed to %s", old.Status)
+	}
+	if current, _ := registry.ByIndex(1); current.Status != beaconclient.ValidatorStatusActiveOngoing {
+		t.Fatalf("unexpected status %s", current.Status)
+	}
+}
+
+func TestValidatorRegistryUpdateErrors(t *testing.T) {
+	registry := NewValidatorRegistry()
+	if err := registry.Update([]*beaconclient.ValidatorData{testValidator(1, "0x01", beaconclient.ValidatorStatusActiveOngoing)}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		validators []*beaconclient.ValidatorData
+	}{
+		{"missing validator", []*beaconclient.ValidatorData{nil}},
+		{"missing pubkey", []*beaconclient.ValidatorData{testValidator(2, "", beaconclient.ValidatorStatusActiveOngoing)}},
+		{"pubkey of another index", []*beaconclient.ValidatorData{testValidator(2, "0x01", beaconclient.ValidatorStatusActiveOngoing)}},
+	}
+	for _, test := range tests {
+		if err := registry.Update(test.validators); err == nil {
+			t.Fatalf("%s: expected an error", test.name)
+		}
+	}
+	// Failed updates leave the registry unchanged
+	if registry.Len() != 1 {
+		t.Fatalf("expected 1 validator, got %d", registry.Len())
+	}
+	if err := registry.Load(nil); err == nil {
+		t.Fatal("expected an error for a missing response")
+	}
+}
+
+func TestValidatorRegistryRefresh(t *testing.T) {
+	node := beaconclient.NewFakeBeaconNode()
+	defer node.Close()
+	node.SetValidators([]*beaconclient.ValidatorData{
+		testValidator(1, "0x01", beaconclient.ValidatorStatusActiveOngoing),
+		testValidator(2, "0x02", beaconclient.ValidatorStatusActiveOngoing),
+	})
+	client, err := beaconclient.NewClient(beaconclient.ClientOpts{Endpoints: []string{node.URL()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	registry := NewValidatorRegistry()
+	if err := registry.Update([]*beaconclient.ValidatorData{
+		testValidator(1, "0x01", beaconclient.ValidatorStatusPendingQueued),
+		testValidator(3, "0x03", beaconclient.ValidatorStatusPendingQueued),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.Refresh(context.Background(), client, "head", []string{"1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if registry.Len() != 2 {
+		t.Fatalf("expected 2 validators, got %d", registry.Len())
+	}
+	active := registry.Filter(beaconclient.ValidatorStatusActive)
+	if len(active) != 1 || active[0].Index != 1 {
+		t.Fatalf("expected validator 1 to be active, got %d active validators", len(active))
+	}
+	pending := registry.Filter(beaconclient.ValidatorStatusPendingQueued, beaconclient.ValidatorStatusExited)
+	if len(pending) != 1 || pending[0].Index != 3 {
+		t.Fatal("validator 3 not left pending")
+	}
+}
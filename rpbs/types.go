@@ -56,4 +56,77 @@ func (e *EncodedRPBSSignature) HashTreeRootWith(hh ssz.HashWalker) (err error) {
 // GetTree ssz hashes the EncodedRPBSSignature object
 func (e *EncodedRPBSSignature) GetTree() (*ssz.Node, error) {
 	return ssz.ProofTree(e)
-}
\ No newline at end of file
+}
+
+// MarshalSSZ ssz marshals the EncodedRPBSSignature object
+func (e *EncodedRPBSSignature) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(e)
+}
+
+// MarshalSSZTo ssz marshals the EncodedRPBSSignature object to a target array
+func (e *EncodedRPBSSignature) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(24)
+
+	fields := e.fields()
+
+	// Offsets (0-5) 'Z1Hat', 'C1Hat', 'S1Hat', 'C2Hat', 'S2Hat', 'M1Hat'
+	for _, field := range fields {
+		dst = ssz.WriteOffset(dst, offset)
+		offset += len(*field)
+	}
+
+	// Fields (0-5) 'Z1Hat', 'C1Hat', 'S1Hat', 'C2Hat', 'S2Hat', 'M1Hat'
+	for _, field := range fields {
+		dst = append(dst, *field...)
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the EncodedRPBSSignature object
+func (e *EncodedRPBSSignature) UnmarshalSSZ(buf []byte) error {
+	size := uint64(len(buf))
+	if size < 24 {
+		return ssz.ErrSize
+	}
+
+	// Offsets (0-5) 'Z1Hat', 'C1Hat', 'S1Hat', 'C2Hat', 'S2Hat', 'M1Hat'
+	var offsets [7]uint64
+	for i := 0; i < 6; i++ {
+		offsets[i] = ssz.ReadOffset(buf[i*4 : (i+1)*4])
+		if offsets[i] > size {
+			return ssz.ErrOffset
+		}
+		if i == 0 && offsets[i] != 24 {
+			return ssz.ErrInvalidVariableOffset
+		}
+		if i > 0 && offsets[i] < offsets[i-1] {
+			return ssz.ErrOffset
+		}
+	}
+	offsets[6] = size
+
+	// Fields (0-5) 'Z1Hat', 'C1Hat', 'S1Hat', 'C2Hat', 'S2Hat', 'M1Hat'
+	for i, field := range e.fields() {
+		*field = string(buf[offsets[i]:offsets[i+1]])
+	}
+
+	return nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the EncodedRPBSSignature object
+func (e *EncodedRPBSSignature) SizeSSZ() (size int) {
+	size = 24
+
+	// Fields (0-5) 'Z1Hat', 'C1Hat', 'S1Hat', 'C2Hat', 'S2Hat', 'M1Hat'
+	for _, field := range e.fields() {
+		size += len(*field)
+	}
+
+	return
+}
+
+func (e *EncodedRPBSSignature) fields() []*string {
+	return []*string{&e.Z1Hat, &e.C1Hat, &e.S1Hat, &e.C2Hat, &e.S2Hat, &e.M1Hat}
+}
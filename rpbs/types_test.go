@@ -0,0 +1,33 @@
+package rpbs
+
+import "testing"
+
+func TestEncodedRPBSSignatureSSZ(t *testing.T) {
+	signature := &EncodedRPBSSignature{
+		Z1Hat: "z1",
+		C1Hat: "c1",
+		S1Hat: "",
+		C2Hat: "c2",
+		S2Hat: "s2",
+		M1Hat: "m1",
+	}
+	data, err := signature.MarshalSSZ()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != signature.SizeSSZ() {
+		t.Fatalf("expected %d bytes, got %d", signature.SizeSSZ(), len(data))
+	}
+
+	res := &EncodedRPBSSignature{}
+	if err := res.UnmarshalSSZ(data); err != nil {
+		t.Fatal(err)
+	}
+	if *res != *signature {
+		t.Fatalf("expected %+v, got %+v", signature, res)
+	}
+
+	if err := res.UnmarshalSSZ(data[:23]); err == nil {
+		t.Fatal("expected an error for a short buffer")
+	}
+}
@@ -0,0 +1,104 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+
+	bundleTypes "github.com/bsn-eng/pon-golang-types/bundles"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ToBuilderBundle decodes the transactions and reverting hashes of the bundle, deriving the transaction count,
+// total gas and bundle hash, and returns the bundle with the bundle hash response for the caller. The bundle is
+// validated against the opts, whose chain id is required so that transactions signed for another chain are rejected
+func (b *JSONrpcBundle) ToBuilderBundle(opts bundleTypes.BundleValidationOpts) (*bundleTypes.BuilderBundle, *JSONrpcBundleHash, error) {
+	if opts.ChainID == nil {
+		return nil, nil, errors.New("chain id missing")
+	}
+	if len(b.Txs) == 0 {
+		return nil, nil, bundleTypes.ErrNoTransactions
+	}
+
+	txs := make([]*types.Transaction, len(b.Txs))
+	totalGas := uint64(0)
+	for i, encodedTx := range b.Txs {
+		txBytes, err := hexutil.Decode(encodedTx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("tx %d: invalid hex: %w", i, err)
+		}
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(txBytes); err != nil {
+			return nil, nil, fmt.Errorf("tx %d: %w", i, err)
+		}
+		txs[i] = tx
+		totalGas += tx.Gas()
+	}
+
+	revertingTxHashes := make([]*common.Hash, len(b.RevertingTxHashes))
+	for i, encodedHash := range b.RevertingTxHashes {
+		txHash := new(common.Hash)
+		if err := txHash.UnmarshalText([]byte(encodedHash)); err != nil {
+			return nil, nil, fmt.Errorf("reverting tx hash %d: %w", i, err)
+		}
+		revertingTxHashes[i] = txHash
+	}
+
+	bundleHash, err := bundleTypes.ComputeBundleHash(txs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bundle := &bundleTypes.BuilderBundle{
+		ID:                     b.ID,
+		BundleHash:             bundleHash.Hex(),
+		Txs:                    txs,
+		BlockNumber:            b.BlockNumber,
+		MinTimestamp:           b.MinTimestamp,
+		MaxTimestamp:           b.MaxTimestamp,
+		RevertingTxHashes:      revertingTxHashes,
+		BundleTransactionCount: uint64(len(txs)),
+		BundleTotalGas:         totalGas,
+	}
+	if err := bundle.Validate(opts); err != nil {
+		return nil, nil, err
+	}
+	return bundle, &JSONrpcBundleHash{BundleHash: bundle.BundleHash}, nil
+}
+
+// JSONrpcBundleFromBuilderBundle encodes the transactions and reverting hashes of the bundle as hex
+func JSONrpcBundleFromBuilderBundle(b *bundleTypes.BuilderBundle) (*JSONrpcBundle, error) {
+	if b == nil {
+		return nil, errors.New("no bundle set")
+	}
+
+	txs := make([]string, len(b.Txs))
+	for i, tx := range b.Txs {
+		if tx == nil {
+			return nil, fmt.Errorf("tx %d: missing", i)
+		}
+		txBytes, err := tx.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("tx %d: %w", i, err)
+		}
+		txs[i] = hexutil.Encode(txBytes)
+	}
+
+	revertingTxHashes := make([]string, len(b.RevertingTxHashes))
+	for i, txHash := range b.RevertingTxHashes {
+		if txHash == nil {
+			return nil, fmt.Errorf("reverting tx hash %d: missing", i)
+		}
+		revertingTxHashes[i] = txHash.Hex()
+	}
+
+	return &JSONrpcBundle{
+		ID:                b.ID,
+		Txs:               txs,
+		BlockNumber:       b.BlockNumber,
+		MinTimestamp:      b.MinTimestamp,
+		MaxTimestamp:      b.MaxTimestamp,
+		RevertingTxHashes: revertingTxHashes,
+	}, nil
+}
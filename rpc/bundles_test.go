@@ -0,0 +1,97 @@
+package rpc
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	bundleTypes "github.com/bsn-eng/pon-golang-types/bundles"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func testEncodedTx(t *testing.T, chainID int64, nonce uint64) (string, common.Hash) {
+	t.Helper()
+	key, err := crypto.ToECDSA(common.LeftPadBytes([]byte{0x01}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := types.SignTx(types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		Gas:      21000,
+		GasPrice: big.NewInt(1),
+		To:       &common.Address{0x02},
+	}), types.LatestSignerForChainID(big.NewInt(chainID)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txBytes, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hexutil.Encode(txBytes), tx.Hash()
+}
+
+func TestToBuilderBundle(t *testing.T) {
+	first, firstHash := testEncodedTx(t, 1, 0)
+	second, _ := testEncodedTx(t, 1, 1)
+	rpcBundle := &JSONrpcBundle{
+		ID:                "bundle",
+		Txs:               []string{first, second},
+		BlockNumber:       100,
+		RevertingTxHashes: []string{firstHash.Hex()},
+	}
+
+	bundle, bundleHash, err := rpcBundle.ToBuilderBundle(bundleTypes.BundleValidationOpts{ChainID: big.NewInt(1), BlockNumber: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bundle.BundleTransactionCount != 2 || bundle.BundleTotalGas != 42000 {
+		t.Fatalf("unexpected count %d and gas %d", bundle.BundleTransactionCount, bundle.BundleTotalGas)
+	}
+	if bundleHash.BundleHash != bundle.BundleHash {
+		t.Fatal("bundle hash response differs from the bundle")
+	}
+	if err := bundle.VerifyBundleHash(); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := JSONrpcBundleFromBuilderBundle(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Txs[0] != first || res.Txs[1] != second || res.RevertingTxHashes[0] != firstHash.Hex() {
+		t.Fatal("bundle changed through conversion")
+	}
+}
+
+func TestToBuilderBundleErrors(t *testing.T) {
+	tx, txHash := testEncodedTx(t, 1, 0)
+	otherChainTx, _ := testEncodedTx(t, 2, 0)
+	opts := bundleTypes.BundleValidationOpts{ChainID: big.NewInt(1)}
+
+	tests := []struct {
+		name     string
+		bundle   *JSONrpcBundle
+		opts     bundleTypes.BundleValidationOpts
+		expected error
+	}{
+		{"no transactions", &JSONrpcBundle{}, opts, bundleTypes.ErrNoTransactions},
+		{"other chain", &JSONrpcBundle{Txs: []string{otherChainTx}}, opts, bundleTypes.ErrInvalidSender},
+		{"unknown reverting tx", &JSONrpcBundle{Txs: []string{tx}, RevertingTxHashes: []string{common.Hash{0x01}.Hex()}}, opts, bundleTypes.ErrUnknownRevertingTx},
+		{"other block", &JSONrpcBundle{Txs: []string{tx}, BlockNumber: 99}, bundleTypes.BundleValidationOpts{ChainID: big.NewInt(1), BlockNumber: 100}, bundleTypes.ErrBlockNumberMismatch},
+		{"missing chain id", &JSONrpcBundle{Txs: []string{tx}, RevertingTxHashes: []string{txHash.Hex()}}, bundleTypes.BundleValidationOpts{}, nil},
+		{"invalid hex", &JSONrpcBundle{Txs: []string{"0xzz"}}, opts, nil},
+	}
+	for _, test := range tests {
+		_, _, err := test.bundle.ToBuilderBundle(test.opts)
+		if err == nil {
+			t.Fatalf("%s: expected an error", test.name)
+		}
+		if test.expected != nil && !errors.Is(err, test.expected) {
+			t.Fatalf("%s: expected %v, got %v", test.name, test.expected, err)
+		}
+	}
+}